@@ -0,0 +1,334 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: api/goods/v1/goods.proto
+
+package goodsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	GoodsService_CreateGood_FullMethodName       = "/goods.v1.GoodsService/CreateGood"
+	GoodsService_GetGood_FullMethodName          = "/goods.v1.GoodsService/GetGood"
+	GoodsService_ListGoods_FullMethodName        = "/goods.v1.GoodsService/ListGoods"
+	GoodsService_UpdateGood_FullMethodName       = "/goods.v1.GoodsService/UpdateGood"
+	GoodsService_DeleteGood_FullMethodName       = "/goods.v1.GoodsService/DeleteGood"
+	GoodsService_ReprioritizeGood_FullMethodName = "/goods.v1.GoodsService/ReprioritizeGood"
+)
+
+// GoodsServiceClient is the client API for GoodsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GoodsServiceClient interface {
+	// CreateGood adds a new good to the project, editor role is required.
+	CreateGood(ctx context.Context, in *CreateGoodRequest, opts ...grpc.CallOption) (*Good, error)
+	// GetGood returns a good of the project, viewer role is required.
+	GetGood(ctx context.Context, in *GetGoodRequest, opts ...grpc.CallOption) (*Good, error)
+	// ListGoods streams goods of the project, viewer role is required.
+	ListGoods(ctx context.Context, in *ListGoodsRequest, opts ...grpc.CallOption) (GoodsService_ListGoodsClient, error)
+	// UpdateGood changes set fields of a good, editor role is required.
+	UpdateGood(ctx context.Context, in *UpdateGoodRequest, opts ...grpc.CallOption) (*Good, error)
+	// DeleteGood removes a good, admin role is required.
+	DeleteGood(ctx context.Context, in *DeleteGoodRequest, opts ...grpc.CallOption) (*Good, error)
+	// ReprioritizeGood changes priority of a good, editor role is required.
+	ReprioritizeGood(ctx context.Context, in *ReprioritizeGoodRequest, opts ...grpc.CallOption) (*ReprioritizeGoodResponse, error)
+}
+
+type goodsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGoodsServiceClient(cc grpc.ClientConnInterface) GoodsServiceClient {
+	return &goodsServiceClient{cc}
+}
+
+func (c *goodsServiceClient) CreateGood(ctx context.Context, in *CreateGoodRequest, opts ...grpc.CallOption) (*Good, error) {
+	out := new(Good)
+	err := c.cc.Invoke(ctx, GoodsService_CreateGood_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) GetGood(ctx context.Context, in *GetGoodRequest, opts ...grpc.CallOption) (*Good, error) {
+	out := new(Good)
+	err := c.cc.Invoke(ctx, GoodsService_GetGood_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) ListGoods(ctx context.Context, in *ListGoodsRequest, opts ...grpc.CallOption) (GoodsService_ListGoodsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoodsService_ServiceDesc.Streams[0], GoodsService_ListGoods_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goodsServiceListGoodsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoodsService_ListGoodsClient interface {
+	Recv() (*Good, error)
+	grpc.ClientStream
+}
+
+type goodsServiceListGoodsClient struct {
+	grpc.ClientStream
+}
+
+func (x *goodsServiceListGoodsClient) Recv() (*Good, error) {
+	m := new(Good)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goodsServiceClient) UpdateGood(ctx context.Context, in *UpdateGoodRequest, opts ...grpc.CallOption) (*Good, error) {
+	out := new(Good)
+	err := c.cc.Invoke(ctx, GoodsService_UpdateGood_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) DeleteGood(ctx context.Context, in *DeleteGoodRequest, opts ...grpc.CallOption) (*Good, error) {
+	out := new(Good)
+	err := c.cc.Invoke(ctx, GoodsService_DeleteGood_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) ReprioritizeGood(ctx context.Context, in *ReprioritizeGoodRequest, opts ...grpc.CallOption) (*ReprioritizeGoodResponse, error) {
+	out := new(ReprioritizeGoodResponse)
+	err := c.cc.Invoke(ctx, GoodsService_ReprioritizeGood_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GoodsServiceServer is the server API for GoodsService service.
+// All implementations must embed UnimplementedGoodsServiceServer
+// for forward compatibility
+type GoodsServiceServer interface {
+	// CreateGood adds a new good to the project, editor role is required.
+	CreateGood(context.Context, *CreateGoodRequest) (*Good, error)
+	// GetGood returns a good of the project, viewer role is required.
+	GetGood(context.Context, *GetGoodRequest) (*Good, error)
+	// ListGoods streams goods of the project, viewer role is required.
+	ListGoods(*ListGoodsRequest, GoodsService_ListGoodsServer) error
+	// UpdateGood changes set fields of a good, editor role is required.
+	UpdateGood(context.Context, *UpdateGoodRequest) (*Good, error)
+	// DeleteGood removes a good, admin role is required.
+	DeleteGood(context.Context, *DeleteGoodRequest) (*Good, error)
+	// ReprioritizeGood changes priority of a good, editor role is required.
+	ReprioritizeGood(context.Context, *ReprioritizeGoodRequest) (*ReprioritizeGoodResponse, error)
+	mustEmbedUnimplementedGoodsServiceServer()
+}
+
+// UnimplementedGoodsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGoodsServiceServer struct {
+}
+
+func (UnimplementedGoodsServiceServer) CreateGood(context.Context, *CreateGoodRequest) (*Good, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGood not implemented")
+}
+func (UnimplementedGoodsServiceServer) GetGood(context.Context, *GetGoodRequest) (*Good, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGood not implemented")
+}
+func (UnimplementedGoodsServiceServer) ListGoods(*ListGoodsRequest, GoodsService_ListGoodsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListGoods not implemented")
+}
+func (UnimplementedGoodsServiceServer) UpdateGood(context.Context, *UpdateGoodRequest) (*Good, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGood not implemented")
+}
+func (UnimplementedGoodsServiceServer) DeleteGood(context.Context, *DeleteGoodRequest) (*Good, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGood not implemented")
+}
+func (UnimplementedGoodsServiceServer) ReprioritizeGood(context.Context, *ReprioritizeGoodRequest) (*ReprioritizeGoodResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReprioritizeGood not implemented")
+}
+func (UnimplementedGoodsServiceServer) mustEmbedUnimplementedGoodsServiceServer() {}
+
+// UnsafeGoodsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GoodsServiceServer will
+// result in compilation errors.
+type UnsafeGoodsServiceServer interface {
+	mustEmbedUnimplementedGoodsServiceServer()
+}
+
+func RegisterGoodsServiceServer(s grpc.ServiceRegistrar, srv GoodsServiceServer) {
+	s.RegisterService(&GoodsService_ServiceDesc, srv)
+}
+
+func _GoodsService_CreateGood_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGoodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).CreateGood(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_CreateGood_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).CreateGood(ctx, req.(*CreateGoodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_GetGood_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGoodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).GetGood(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_GetGood_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).GetGood(ctx, req.(*GetGoodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_ListGoods_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListGoodsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoodsServiceServer).ListGoods(m, &goodsServiceListGoodsServer{stream})
+}
+
+type GoodsService_ListGoodsServer interface {
+	Send(*Good) error
+	grpc.ServerStream
+}
+
+type goodsServiceListGoodsServer struct {
+	grpc.ServerStream
+}
+
+func (x *goodsServiceListGoodsServer) Send(m *Good) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GoodsService_UpdateGood_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGoodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).UpdateGood(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_UpdateGood_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).UpdateGood(ctx, req.(*UpdateGoodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_DeleteGood_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGoodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).DeleteGood(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_DeleteGood_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).DeleteGood(ctx, req.(*DeleteGoodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_ReprioritizeGood_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReprioritizeGoodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).ReprioritizeGood(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_ReprioritizeGood_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).ReprioritizeGood(ctx, req.(*ReprioritizeGoodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GoodsService_ServiceDesc is the grpc.ServiceDesc for GoodsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GoodsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "goods.v1.GoodsService",
+	HandlerType: (*GoodsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateGood",
+			Handler:    _GoodsService_CreateGood_Handler,
+		},
+		{
+			MethodName: "GetGood",
+			Handler:    _GoodsService_GetGood_Handler,
+		},
+		{
+			MethodName: "UpdateGood",
+			Handler:    _GoodsService_UpdateGood_Handler,
+		},
+		{
+			MethodName: "DeleteGood",
+			Handler:    _GoodsService_DeleteGood_Handler,
+		},
+		{
+			MethodName: "ReprioritizeGood",
+			Handler:    _GoodsService_ReprioritizeGood_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListGoods",
+			Handler:       _GoodsService_ListGoods_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/goods/v1/goods.proto",
+}
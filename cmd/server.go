@@ -1,24 +1,40 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"flag"
 	"fmt"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
+	"github.com/nats-io/nats.go"
 	"goods-manager/internal/app"
+	"goods-manager/internal/auth/usecase"
 	"goods-manager/internal/cache/redis"
-	"log"
+	"goods-manager/internal/logger/workers"
+	"goods-manager/internal/logging"
+	"goods-manager/internal/ratelimit"
+	"goods-manager/internal/stream"
+	usecase2 "goods-manager/internal/webhook/usecase"
+	"log/slog"
 	"os"
 	"strconv"
+	"time"
 )
 
 const (
-	defaultAddress = ":8080"
+	defaultAddress           = ":8080"
+	defaultGRPCAddress       = ":9090"
+	defaultIdempotencyWindow = 24 * time.Hour
+	serviceName              = "goods-manager"
 )
 
 func init() {
 	err := godotenv.Load()
 	if err != nil {
-		log.Fatal("Error loading .env file")
+		slog.Error("Error loading .env file", slog.Any("error", err))
+		os.Exit(1)
 	}
 }
 
@@ -31,25 +47,57 @@ func init() {
 // @host		localhost:8080
 // @BasePath	/
 func main() {
-	//prepare database
-	host := os.Getenv("DB_HOST")
-	port := os.Getenv("DB_PORT")
-	user := os.Getenv("DB_USER")
-	password := os.Getenv("DB_PASSWORD")
-	dbname := os.Getenv("DB_NAME")
+	logger, err := logging.New(os.Stdout, os.Getenv("LOG_FORMAT"), os.Getenv("LOG_LEVEL"))
+	if err != nil {
+		slog.Error("failed init logger", slog.Any("error", err))
+		os.Exit(1)
+	}
 
-	pgInfo := fmt.Sprintf("host = %s port = %s "+
-		"user = %s password = %s dbname = %s sslmode = disable", host, port, user, password, dbname)
+	slog.SetDefault(logger)
 
-	db, err := app.ConnectToPostgres(pgInfo)
+	// `logger` command runs logger worker only
+	if len(os.Args) > 1 && os.Args[1] == "logger" {
+		if err := runLogger(logger); err != nil {
+			logger.Error("logger stopped", slog.Any("error", err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	// `backfill [-restart]` command writes snapshots of goods to the log
+	if len(os.Args) > 1 && os.Args[1] == "backfill" {
+		if err := runBackfill(logger, os.Args[2:]); err != nil {
+			logger.Error("backfill stopped", slog.Any("error", err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := run(logger); err != nil {
+		logger.Error("server stopped", slog.Any("error", err))
+		os.Exit(1)
+	}
+}
+
+// run connects to all dependencies and serves HTTP until error.
+// Connections are closed before return.
+func run(logger *slog.Logger) error {
+	shutdownTracer, err := initTracer(serviceName, logger)
 	if err != nil {
-		log.Panicln("failed connect to database:", err)
+		return err
+	}
+
+	defer shutdownTracer()
+
+	db, err := connectToPostgres()
+	if err != nil {
+		return err
 	}
 
 	defer func() {
 		err := db.Close()
 		if err != nil {
-			log.Panicln("failed close database connection:", err)
+			logger.Error("failed close database connection", slog.Any("error", err))
 		}
 	}()
 
@@ -59,28 +107,26 @@ func main() {
 	redisDB := os.Getenv("REDIS_DB")
 	redisDBInt, err := strconv.Atoi(redisDB)
 	if err != nil {
-		log.Panicln("failed convert redis db to int:", err)
+		return fmt.Errorf("failed convert redis db to int: %w", err)
 	}
 
 	redisClient, err := app.ConnectToRedis(redisAddr, redisPassword, redisDBInt)
 	if err != nil {
-		log.Panicln("failed connect to redis:", err)
+		return fmt.Errorf("failed connect to redis: %w", err)
 	}
 
 	cache := redis.NewCache(redisClient)
 
 	// connect to clickhouse
-	clickhouseAddr := os.Getenv("CLICKHOUSE_HOST") + ":" + os.Getenv("CLICKHOUSE_PORT")
-	clickhouseClient, err := app.ConnectToClickHouse(clickhouseAddr)
+	clickhouseClient, err := connectToClickHouse()
 	if err != nil {
-		log.Panicln("failed connect to clickhouse:", err)
+		return err
 	}
 
 	// connect to nats
-	natsAddr := os.Getenv("NATS_HOST") + ":" + os.Getenv("NATS_PORT")
-	natsClient, err := app.ConnectToNats(natsAddr)
+	natsClient, err := connectToNats(logger)
 	if err != nil {
-		log.Panicln("failed connect to nats:", err)
+		return err
 	}
 
 	// Start Server
@@ -89,7 +135,386 @@ func main() {
 		address = defaultAddress
 	}
 
-	if err := app.RunHTTPServe(address, db, cache, natsClient, clickhouseClient); err != nil {
-		log.Panic(err)
+	grpcAddress, ok := os.LookupEnv("GRPC_ADDRESS")
+	if !ok {
+		grpcAddress = defaultGRPCAddress
+	}
+
+	rateLimit, err := rateLimitConfig(os.Getenv("RATE_LIMIT_DEFAULT"), os.Getenv("RATE_LIMIT_ROUTES"), os.Getenv("RATE_LIMIT_IP"))
+	if err != nil {
+		return err
+	}
+
+	idempotencyWindow := defaultIdempotencyWindow
+	if window := os.Getenv("IDEMPOTENCY_WINDOW"); window != "" {
+		idempotencyWindow, err = time.ParseDuration(window)
+		if err != nil {
+			return fmt.Errorf("failed parse IDEMPOTENCY_WINDOW: %w", err)
+		}
+	}
+
+	webhook, err := webhookConfig()
+	if err != nil {
+		return err
+	}
+
+	streamConfig, err := streamConfig()
+	if err != nil {
+		return err
+	}
+
+	loggerConfig, err := loggerConfig()
+	if err != nil {
+		return err
+	}
+
+	config := app.HTTPConfig{
+		Address:  address,
+		AdminKey: os.Getenv("AUTH_ADMIN_KEY"),
+		JWT: usecase.JWTConfig{
+			HS256Secret:        os.Getenv("JWT_HS256_SECRET"),
+			RS256PublicKeyFile: os.Getenv("JWT_RS256_PUBLIC_KEY_FILE"),
+			JWKSFile:           os.Getenv("JWT_JWKS_FILE"),
+			Issuer:             os.Getenv("JWT_ISSUER"),
+			Audience:           os.Getenv("JWT_AUDIENCE"),
+			RolesClaim:         os.Getenv("JWT_ROLES_CLAIM"),
+		},
+		RateLimit:         rateLimit,
+		IdempotencyWindow: idempotencyWindow,
+		Webhook:           webhook,
+		GRPCAddress:       grpcAddress,
+		Stream:            streamConfig,
+		Logger:            loggerConfig,
+	}
+
+	return app.RunHTTPServe(config, db, redisClient, cache, natsClient, clickhouseClient, logger)
+}
+
+// runLogger connects to dependencies of logger pipeline and runs logger worker until SIGINT or SIGTERM.
+// Connections are closed before return.
+func runLogger(logger *slog.Logger) error {
+	shutdownTracer, err := initTracer(serviceName+"-logger", logger)
+	if err != nil {
+		return err
+	}
+
+	defer shutdownTracer()
+
+	// dead letters and outbox are stored in database
+	db, err := connectToPostgres()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err := db.Close()
+		if err != nil {
+			logger.Error("failed close database connection", slog.Any("error", err))
+		}
+	}()
+
+	clickhouseClient, err := connectToClickHouse()
+	if err != nil {
+		return err
+	}
+
+	natsClient, err := connectToNats(logger)
+	if err != nil {
+		return err
+	}
+
+	defer natsClient.Close()
+
+	config, err := loggerConfig()
+	if err != nil {
+		return err
+	}
+
+	return app.RunLogger(config, db, natsClient, clickhouseClient, logger)
+}
+
+// runBackfill connects to database and ClickHouse and writes snapshot events of goods.
+// Connections are closed before return.
+func runBackfill(logger *slog.Logger, args []string) error {
+	var config app.BackfillConfig
+
+	flags := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	flags.BoolVar(&config.Restart, "restart", false, "start from the first good instead of the checkpoint")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if env := os.Getenv("BACKFILL_BATCH_SIZE"); env != "" {
+		size, err := strconv.Atoi(env)
+		if err != nil || size < 1 {
+			return fmt.Errorf("failed parse BACKFILL_BATCH_SIZE: must be positive integer")
+		}
+		config.BatchSize = size
+	}
+
+	shutdownTracer, err := initTracer(serviceName+"-backfill", logger)
+	if err != nil {
+		return err
+	}
+
+	defer shutdownTracer()
+
+	db, err := connectToPostgres()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err := db.Close()
+		if err != nil {
+			logger.Error("failed close database connection", slog.Any("error", err))
+		}
+	}()
+
+	clickhouseClient, err := connectToClickHouse()
+	if err != nil {
+		return err
+	}
+
+	return app.RunBackfill(config, db, clickhouseClient, logger)
+}
+
+// initTracer inits tracing of the service, returned function flushes spans
+func initTracer(name string, logger *slog.Logger) (func(), error) {
+	shutdownTracer, err := app.InitTracer(context.Background(), name, os.Getenv("OTEL_TRACES_EXPORTER"))
+	if err != nil {
+		return nil, fmt.Errorf("failed init tracer: %w", err)
+	}
+
+	return func() {
+		if err := shutdownTracer(context.Background()); err != nil {
+			logger.Error("failed shutdown tracer", slog.Any("error", err))
+		}
+	}, nil
+}
+
+func connectToPostgres() (*sql.DB, error) {
+	host := os.Getenv("DB_HOST")
+	port := os.Getenv("DB_PORT")
+	user := os.Getenv("DB_USER")
+	password := os.Getenv("DB_PASSWORD")
+	dbname := os.Getenv("DB_NAME")
+
+	pgInfo := fmt.Sprintf("host = %s port = %s "+
+		"user = %s password = %s dbname = %s sslmode = disable", host, port, user, password, dbname)
+
+	db, err := app.ConnectToPostgres(pgInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed connect to database: %w", err)
+	}
+
+	return db, nil
+}
+
+func connectToClickHouse() (driver.Conn, error) {
+	clickhouseAddr := os.Getenv("CLICKHOUSE_HOST") + ":" + os.Getenv("CLICKHOUSE_PORT")
+	clickhouseClient, err := app.ConnectToClickHouse(clickhouseAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed connect to clickhouse: %w", err)
+	}
+
+	return clickhouseClient, nil
+}
+
+func connectToNats(logger *slog.Logger) (*nats.Conn, error) {
+	natsAddr := os.Getenv("NATS_HOST") + ":" + os.Getenv("NATS_PORT")
+	natsClient, err := app.ConnectToNats(natsAddr, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed connect to nats: %w", err)
+	}
+
+	return natsClient, nil
+}
+
+// rateLimitConfig parses default rule, rules of routes and rule of client IP, empty values disable limits
+func rateLimitConfig(defaultRule, routes, ipRule string) (ratelimit.Config, error) {
+	var config ratelimit.Config
+	var err error
+
+	if defaultRule != "" {
+		config.Default, err = ratelimit.ParseRule(defaultRule)
+		if err != nil {
+			return config, fmt.Errorf("failed parse RATE_LIMIT_DEFAULT: %w", err)
+		}
+	}
+
+	config.Routes, err = ratelimit.ParseRoutes(routes)
+	if err != nil {
+		return config, fmt.Errorf("failed parse RATE_LIMIT_ROUTES: %w", err)
+	}
+
+	if ipRule != "" {
+		config.IP, err = ratelimit.ParseRule(ipRule)
+		if err != nil {
+			return config, fmt.Errorf("failed parse RATE_LIMIT_IP: %w", err)
+		}
+	}
+
+	return config, nil
+}
+
+// webhookConfig parses configuration of webhook deliveries, empty values are defaults
+func webhookConfig() (usecase2.Config, error) {
+	var config usecase2.Config
+
+	for name, value := range map[string]*int{
+		"WEBHOOK_MAX_ATTEMPTS":  &config.MaxAttempts,
+		"WEBHOOK_DISABLE_AFTER": &config.DisableAfter,
+	} {
+		if env := os.Getenv(name); env != "" {
+			parsed, err := strconv.Atoi(env)
+			if err != nil || parsed < 1 {
+				return config, fmt.Errorf("failed parse %s: must be positive integer", name)
+			}
+			*value = parsed
+		}
 	}
+
+	for name, value := range map[string]*time.Duration{
+		"WEBHOOK_BACKOFF_BASE": &config.BackoffBase,
+		"WEBHOOK_BACKOFF_MAX":  &config.BackoffMax,
+		"WEBHOOK_TIMEOUT":      &config.Timeout,
+	} {
+		if env := os.Getenv(name); env != "" {
+			parsed, err := time.ParseDuration(env)
+			if err != nil {
+				return config, fmt.Errorf("failed parse %s: %w", name, err)
+			}
+			*value = parsed
+		}
+	}
+
+	if env := os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS"); env != "" {
+		allow, err := strconv.ParseBool(env)
+		if err != nil {
+			return config, fmt.Errorf("failed parse WEBHOOK_ALLOW_PRIVATE_NETWORKS: %w", err)
+		}
+		config.AllowPrivateNetworks = allow
+	}
+
+	return config, nil
+}
+
+// streamConfig parses configuration of change stream, empty values are defaults
+func streamConfig() (stream.Config, error) {
+	var config stream.Config
+
+	if env := os.Getenv("STREAM_HEARTBEAT"); env != "" {
+		heartbeat, err := time.ParseDuration(env)
+		if err != nil {
+			return config, fmt.Errorf("failed parse STREAM_HEARTBEAT: %w", err)
+		}
+		config.Heartbeat = heartbeat
+	}
+
+	if env := os.Getenv("STREAM_BUFFER_SIZE"); env != "" {
+		size, err := strconv.Atoi(env)
+		if err != nil || size < 1 {
+			return config, fmt.Errorf("failed parse STREAM_BUFFER_SIZE: must be positive integer")
+		}
+		config.BufferSize = size
+	}
+
+	return config, nil
+}
+
+// loggerConfig parses configuration of logger pipeline, empty values are defaults
+func loggerConfig() (app.LoggerConfig, error) {
+	var config app.LoggerConfig
+	var err error
+
+	if env := os.Getenv("LOGGER_STANDALONE"); env != "" {
+		config.Standalone, err = strconv.ParseBool(env)
+		if err != nil {
+			return config, fmt.Errorf("failed parse LOGGER_STANDALONE: %w", err)
+		}
+	}
+
+	config.Worker, err = loggerWorkerConfig()
+	if err != nil {
+		return config, err
+	}
+
+	config.JetStream, err = loggerJetStreamConfig()
+	if err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+// loggerJetStreamConfig parses configuration of logger pipeline on JetStream, empty values are defaults
+func loggerJetStreamConfig() (workers.JetStreamConfig, error) {
+	config := workers.JetStreamConfig{
+		Stream:   os.Getenv("LOGGER_STREAM"),
+		Consumer: os.Getenv("LOGGER_CONSUMER"),
+	}
+
+	if env := os.Getenv("LOGGER_JETSTREAM"); env != "" {
+		enabled, err := strconv.ParseBool(env)
+		if err != nil {
+			return config, fmt.Errorf("failed parse LOGGER_JETSTREAM: %w", err)
+		}
+		config.Enabled = enabled
+	}
+
+	if env := os.Getenv("LOGGER_MAX_DELIVER"); env != "" {
+		maxDeliver, err := strconv.Atoi(env)
+		if err != nil || maxDeliver < 1 {
+			return config, fmt.Errorf("failed parse LOGGER_MAX_DELIVER: must be positive integer")
+		}
+		config.MaxDeliver = maxDeliver
+	}
+
+	if env := os.Getenv("LOGGER_ACK_WAIT"); env != "" {
+		ackWait, err := time.ParseDuration(env)
+		if err != nil {
+			return config, fmt.Errorf("failed parse LOGGER_ACK_WAIT: %w", err)
+		}
+		config.AckWait = ackWait
+	}
+
+	return config, nil
+}
+
+// loggerWorkerConfig parses configuration of logger worker on core NATS, empty values are defaults
+func loggerWorkerConfig() (workers.LoggerConfig, error) {
+	config := workers.LoggerConfig{
+		QueueGroup: os.Getenv("LOGGER_QUEUE_GROUP"),
+		Overflow:   workers.OverflowPolicy(os.Getenv("LOGGER_OVERFLOW")),
+	}
+
+	if config.Overflow != "" && !config.Overflow.Valid() {
+		return config, fmt.Errorf("failed parse LOGGER_OVERFLOW: must be one of block, drop_oldest, spill")
+	}
+
+	for name, value := range map[string]*int{
+		"LOGGER_BATCH_SIZE":    &config.MaxSize,
+		"LOGGER_BUFFER_SIZE":   &config.BufferSize,
+		"LOGGER_FLUSH_WORKERS": &config.FlushWorkers,
+		"LOGGER_PENDING_LIMIT": &config.PendingLimit,
+	} {
+		if env := os.Getenv(name); env != "" {
+			parsed, err := strconv.Atoi(env)
+			if err != nil || parsed < 1 {
+				return config, fmt.Errorf("failed parse %s: must be positive integer", name)
+			}
+			*value = parsed
+		}
+	}
+
+	if env := os.Getenv("LOGGER_BATCH_LATENCY"); env != "" {
+		latency, err := time.ParseDuration(env)
+		if err != nil {
+			return config, fmt.Errorf("failed parse LOGGER_BATCH_LATENCY: %w", err)
+		}
+		config.MaxLatency = latency
+	}
+
+	return config, nil
 }
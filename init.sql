@@ -2,9 +2,13 @@ CREATE TABLE IF NOT EXISTS projects
 (
     id         SERIAL PRIMARY KEY,
     name       VARCHAR(255) NOT NULL,
+    removed    BOOLEAN      NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP    NOT NULL DEFAULT NOW()
 );
 
+-- column of projects created by older versions
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS removed BOOLEAN NOT NULL DEFAULT FALSE;
+
 INSERT INTO projects (name)
 VALUES ('Project 1');
 
@@ -19,4 +23,83 @@ CREATE TABLE IF NOT EXISTS goods
     created_at  TIMESTAMP    NOT NULL DEFAULT NOW()
 );
 
-CREATE INDEX IF NOT EXISTS idx_goods_name ON goods (name);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_goods_name ON goods (name);
+
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id          SERIAL PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    prefix      VARCHAR(16)  NOT NULL,
+    key_hash    CHAR(64)     NOT NULL UNIQUE,
+    project_ids INT[]        NOT NULL,
+    role        VARCHAR(16)  NOT NULL DEFAULT 'editor',
+    revoked     BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhooks
+(
+    id          SERIAL PRIMARY KEY,
+    project_id  INT           NOT NULL REFERENCES projects (id),
+    url         VARCHAR(2048) NOT NULL,
+    secret      VARCHAR(64)   NOT NULL,
+    event_types TEXT[]        NOT NULL DEFAULT '{}',
+    enabled     BOOLEAN       NOT NULL DEFAULT TRUE,
+    failures    INT           NOT NULL DEFAULT 0,
+    created_at  TIMESTAMP     NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_project_id ON webhooks (project_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries
+(
+    id              BIGSERIAL PRIMARY KEY,
+    webhook_id      INT       NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id        UUID      NOT NULL,
+    event_type      TEXT      NOT NULL,
+    payload         JSONB     NOT NULL,
+    status          TEXT      NOT NULL DEFAULT 'pending',
+    attempts        INT       NOT NULL DEFAULT 0,
+    response_status INT,
+    error           TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id, id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id              BIGSERIAL PRIMARY KEY,
+    subject         TEXT      NOT NULL,
+    header          JSONB     NOT NULL DEFAULT '{}',
+    data            BYTEA     NOT NULL,
+    attempts        INT       NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at         TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (id) WHERE sent_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_sent_at ON outbox (sent_at) WHERE sent_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS dead_letters
+(
+    id          BIGSERIAL PRIMARY KEY,
+    subject     TEXT      NOT NULL,
+    header      JSONB     NOT NULL DEFAULT '{}',
+    data        BYTEA     NOT NULL,
+    reason      TEXT      NOT NULL,
+    attempts    INT       NOT NULL DEFAULT 1,
+    replayed_at TIMESTAMP,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS backfill_checkpoints
+(
+    name       TEXT PRIMARY KEY,
+    last_id    INT       NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/httperror"
+	"goods-manager/internal/validation"
+	"net/http"
+)
+
+// AnalyticsController serves aggregates of events of goods of a project by routes `/v2/projects/:projectId/analytics/*`
+type AnalyticsController struct {
+	analyticsUsecase domain.AnalyticsUsecase
+}
+
+// TimeSeries this function is used for count events of goods of the project by time buckets.
+//
+// @Summary		Get counts of events by time buckets
+// @Description	Counts of events of goods of the project by types and time buckets, buckets without events are omitted.
+// @Description	Default time range is the last 30 days, it is rounded to hours.
+// @Tags		analytics
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		projectId	path		int			true	"Project ID"	minimum(1)
+// @Param		type		query		[]string	false	"Types of events, all types by default"	collectionFormat(multi)	Enums(good.created, good.updated, good.deleted, good.reprioritized, good.priority_changed, good.snapshot)
+// @Param		bucket		query		string		false	"Size of time bucket"	Enums(hour, day, week, month)	default(day)
+// @Param		from		query		string		false	"Inclusive start of time range, RFC 3339"	format(date-time)
+// @Param		to			query		string		false	"Exclusive end of time range, RFC 3339"		format(date-time)
+//
+// @Success		200		{object}	TimeSeriesResponse	"Counts of events in order of buckets"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/analytics/events		[get]
+func (a *AnalyticsController) TimeSeries(c *gin.Context) {
+	var path ProjectPath
+	if !validation.Bind(c, &path, c.ShouldBindUri) {
+		return
+	}
+
+	var query TimeSeriesQuery
+	if !validation.Bind(c, &query, c.ShouldBindQuery) {
+		return
+	}
+
+	points, err := a.analyticsUsecase.TimeSeries(c, entity.AnalyticsFilter{
+		ProjectId: path.ProjectId,
+		Types:     query.Types,
+		From:      query.From,
+		To:        query.To,
+		Bucket:    query.Bucket,
+	})
+	if err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, TimeSeriesResponse{Bucket: query.Bucket, Points: points})
+}
+
+// TopGoods this function is used for get goods of the project with the most events.
+//
+// @Summary		Get top goods by count of events
+// @Description	Goods of the project with the most events of the types, e.g. `good.reprioritized` for the most reordered goods.
+// @Description	Default time range is the last 30 days, it is rounded to days.
+// @Tags		analytics
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		projectId	path		int			true	"Project ID"	minimum(1)
+// @Param		type		query		[]string	false	"Types of events, all types by default"	collectionFormat(multi)	Enums(good.created, good.updated, good.deleted, good.reprioritized, good.priority_changed, good.snapshot)
+// @Param		from		query		string		false	"Inclusive start of time range, RFC 3339"	format(date-time)
+// @Param		to			query		string		false	"Exclusive end of time range, RFC 3339"		format(date-time)
+// @Param		limit		query		int			false	"Count of goods"	minimum(1)	maximum(100)	default(10)
+//
+// @Success		200		{object}	TopGoodsResponse	"Goods with the most events first"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/analytics/top-goods		[get]
+func (a *AnalyticsController) TopGoods(c *gin.Context) {
+	var path ProjectPath
+	if !validation.Bind(c, &path, c.ShouldBindUri) {
+		return
+	}
+
+	var query TopGoodsQuery
+	if !validation.Bind(c, &query, c.ShouldBindQuery) {
+		return
+	}
+
+	goods, err := a.analyticsUsecase.TopGoods(c, entity.AnalyticsFilter{
+		ProjectId: path.ProjectId,
+		Types:     query.Types,
+		From:      query.From,
+		To:        query.To,
+		Limit:     query.Limit,
+	})
+	if err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, TopGoodsResponse{Goods: goods})
+}
+
+func NewAnalyticsController(analyticsUsecase domain.AnalyticsUsecase) *AnalyticsController {
+	return &AnalyticsController{analyticsUsecase: analyticsUsecase}
+}
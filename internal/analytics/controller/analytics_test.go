@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/httperror"
+	"goods-manager/internal/validation"
+	"goods-manager/mocks"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAnalyticsController(t *testing.T) {
+	from := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		url    string
+		method string
+		filter *entity.AnalyticsFilter
+		code   int
+	}{
+		{
+			name:   "time series by default bucket",
+			url:    "/v2/projects/2/analytics/events?type=good.created&type=good.deleted",
+			method: "TimeSeries",
+			filter: &entity.AnalyticsFilter{ProjectId: 2, Types: []entity.EventType{entity.EventGoodCreated, entity.EventGoodDeleted}, Bucket: entity.BucketDay},
+			code:   http.StatusOK,
+		},
+		{
+			name:   "time series by week",
+			url:    "/v2/projects/2/analytics/events?bucket=week&from=2026-10-01T00:00:00Z",
+			method: "TimeSeries",
+			filter: &entity.AnalyticsFilter{ProjectId: 2, From: from, Bucket: entity.BucketWeek},
+			code:   http.StatusOK,
+		},
+		{
+			name: "unknown bucket",
+			url:  "/v2/projects/2/analytics/events?bucket=minute",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "unknown type",
+			url:  "/v2/projects/2/analytics/events?type=good.moved",
+			code: http.StatusBadRequest,
+		},
+		{
+			name:   "top reordered goods",
+			url:    "/v2/projects/2/analytics/top-goods?type=good.reprioritized&limit=5",
+			method: "TopGoods",
+			filter: &entity.AnalyticsFilter{ProjectId: 2, Types: []entity.EventType{entity.EventGoodReprioritized}, Limit: 5},
+			code:   http.StatusOK,
+		},
+		{
+			name: "too many top goods",
+			url:  "/v2/projects/2/analytics/top-goods?limit=1000",
+			code: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			if err := validation.Register(); err != nil {
+				t.Fatal(err)
+			}
+
+			analyticsUsecase := mocks.NewAnalyticsUsecase(t)
+			switch tt.method {
+			case "TimeSeries":
+				analyticsUsecase.On("TimeSeries", mock.Anything, *tt.filter).Return([]*entity.SeriesPoint{}, nil)
+			case "TopGoods":
+				analyticsUsecase.On("TopGoods", mock.Anything, *tt.filter).Return([]*entity.GoodCount{}, nil)
+			}
+
+			analyticsController := NewAnalyticsController(analyticsUsecase)
+			r := gin.New()
+			r.Use(httperror.Middleware(slog.New(slog.NewTextHandler(io.Discard, nil))))
+			r.GET("/v2/projects/:projectId/analytics/events", analyticsController.TimeSeries)
+			r.GET("/v2/projects/:projectId/analytics/top-goods", analyticsController.TopGoods)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			assert.Equal(t, tt.code, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"goods-manager/internal/domain/entity"
+	"time"
+)
+
+type ProjectPath struct {
+	ProjectId int `uri:"projectId" binding:"required,min=1"`
+}
+
+// RangeQuery is time range and types of counted events, empty values are defaults
+type RangeQuery struct {
+	Types []entity.EventType `form:"type" binding:"dive,oneof=good.created good.updated good.deleted good.reprioritized good.priority_changed good.snapshot"`
+	From  time.Time          `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To    time.Time          `form:"to" time_format:"2006-01-02T15:04:05Z07:00" binding:"omitempty,gtfield=From"`
+}
+
+type TimeSeriesQuery struct {
+	RangeQuery
+	Bucket entity.Bucket `form:"bucket,default=day" binding:"oneof=hour day week month"`
+}
+
+type TopGoodsQuery struct {
+	RangeQuery
+	Limit int `form:"limit,default=10" binding:"min=1,max=100"`
+}
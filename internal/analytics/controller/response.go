@@ -0,0 +1,12 @@
+package controller
+
+import "goods-manager/internal/domain/entity"
+
+type TimeSeriesResponse struct {
+	Bucket entity.Bucket         `json:"bucket"`
+	Points []*entity.SeriesPoint `json:"points"`
+}
+
+type TopGoodsResponse struct {
+	Goods []*entity.GoodCount `json:"goods"`
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
+	"time"
+)
+
+// bucketExpressions are expressions of start of bucket of `Hour` column of `goods_events_hourly`
+var bucketExpressions = map[entity.Bucket]string{
+	entity.BucketHour:  "Hour",
+	entity.BucketDay:   "toStartOfDay(Hour)",
+	entity.BucketWeek:  "toDateTime(toMonday(Hour), 'UTC')",
+	entity.BucketMonth: "toDateTime(toStartOfMonth(Hour), 'UTC')",
+}
+
+// analyticsRepository reads materialized views of `goods` table.
+//
+// Views store states of distinct event IDs instead of counts, so duplicates of redelivered events aren't counted.
+type analyticsRepository struct {
+	conn driver.Conn
+}
+
+// TimeSeries counts events by `goods_events_hourly`, time range is rounded to hours
+func (a *analyticsRepository) TimeSeries(ctx context.Context, filter entity.AnalyticsFilter) (_ []*entity.SeriesPoint, err error) {
+	ctx, span := tracing.Start(ctx, "analyticsRepository.TimeSeries")
+	defer tracing.End(span, &err)
+
+	bucket, ok := bucketExpressions[filter.Bucket]
+	if !ok {
+		return nil, fmt.Errorf("unknown bucket %q", filter.Bucket)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS Start, EventType, uniqExactMerge(Events) AS Count
+		FROM goods_events_hourly
+		WHERE ProjectId = ? AND Hour >= toStartOfHour(?) AND Hour < ? AND (empty(?) OR has(?, EventType))
+		GROUP BY Start, EventType
+		ORDER BY Start, EventType
+	`, bucket)
+
+	types := typeNames(filter.Types)
+	rows, err := a.conn.Query(ctx, query, filter.ProjectId, filter.From, filter.To, types, types)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeRows(rows, &err)
+
+	points := make([]*entity.SeriesPoint, 0)
+	for rows.Next() {
+		var start time.Time
+		var eventType string
+		var point entity.SeriesPoint
+		if err := rows.Scan(&start, &eventType, &point.Count); err != nil {
+			return nil, err
+		}
+
+		point.Bucket = start.UTC()
+		point.Type = entity.EventType(eventType)
+		points = append(points, &point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// TopGoods counts events by `goods_events_daily`, time range is rounded to days
+func (a *analyticsRepository) TopGoods(ctx context.Context, filter entity.AnalyticsFilter) (_ []*entity.GoodCount, err error) {
+	ctx, span := tracing.Start(ctx, "analyticsRepository.TopGoods")
+	defer tracing.End(span, &err)
+
+	query := `
+		SELECT Id, uniqExactMerge(Events) AS Count
+		FROM goods_events_daily
+		WHERE ProjectId = ? AND Day >= toDate(?, 'UTC') AND Day < toDate(?, 'UTC') AND (empty(?) OR has(?, EventType))
+		GROUP BY Id
+		ORDER BY Count DESC, Id
+		LIMIT ?
+	`
+
+	types := typeNames(filter.Types)
+	rows, err := a.conn.Query(ctx, query, filter.ProjectId, filter.From, endOfDays(filter.To), types, types, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeRows(rows, &err)
+
+	goods := make([]*entity.GoodCount, 0)
+	for rows.Next() {
+		var id int32
+		var good entity.GoodCount
+		if err := rows.Scan(&id, &good.Count); err != nil {
+			return nil, err
+		}
+
+		good.GoodId = int(id)
+		goods = append(goods, &good)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return goods, nil
+}
+
+// endOfDays rounds exclusive end of time range up to midnight in UTC, so day of `to` is counted unless `to` is midnight
+func endOfDays(to time.Time) time.Time {
+	to = to.UTC()
+	day := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	if day.Equal(to) {
+		return day
+	}
+
+	return day.AddDate(0, 0, 1)
+}
+
+// typeNames converts types to strings, so they are bound as array of strings
+func typeNames(types []entity.EventType) []string {
+	names := make([]string, 0, len(types))
+	for _, eventType := range types {
+		names = append(names, string(eventType))
+	}
+
+	return names
+}
+
+func closeRows(rows driver.Rows, err *error) {
+	if closeErr := rows.Close(); closeErr != nil && *err == nil {
+		*err = fmt.Errorf("failed close rows: %w", closeErr)
+	}
+}
+
+func NewAnalyticsRepository(conn driver.Conn) domain.AnalyticsRepository {
+	return &analyticsRepository{conn: conn}
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_endOfDays(t *testing.T) {
+	midnight := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		to   time.Time
+		want time.Time
+	}{
+		{"midnight isn't rounded", midnight, midnight},
+		{"now counts today", midnight.Add(15*time.Hour + 30*time.Minute), midnight.AddDate(0, 0, 1)},
+		{"just after midnight", midnight.Add(time.Millisecond), midnight.AddDate(0, 0, 1)},
+		{"other zone is converted to UTC", time.Date(2026, time.October, 19, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60)), midnight},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, endOfDays(tt.to))
+		})
+	}
+}
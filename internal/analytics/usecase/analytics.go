@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
+	"time"
+)
+
+type analyticsUsecase struct {
+	analyticsRepo domain.AnalyticsRepository
+	now           func() time.Time
+}
+
+func (a *analyticsUsecase) TimeSeries(ctx context.Context, filter entity.AnalyticsFilter) (_ []*entity.SeriesPoint, err error) {
+	ctx, span := tracing.Start(ctx, "analyticsUsecase.TimeSeries")
+	defer tracing.End(span, &err)
+
+	return a.analyticsRepo.TimeSeries(ctx, a.withDefaultRange(filter))
+}
+
+func (a *analyticsUsecase) TopGoods(ctx context.Context, filter entity.AnalyticsFilter) (_ []*entity.GoodCount, err error) {
+	ctx, span := tracing.Start(ctx, "analyticsUsecase.TopGoods")
+	defer tracing.End(span, &err)
+
+	return a.analyticsRepo.TopGoods(ctx, a.withDefaultRange(filter))
+}
+
+// withDefaultRange sets missing end of time range to now and missing start to `entity.DefaultAnalyticsRange` before end
+func (a *analyticsUsecase) withDefaultRange(filter entity.AnalyticsFilter) entity.AnalyticsFilter {
+	if filter.To.IsZero() {
+		filter.To = a.now().UTC()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-entity.DefaultAnalyticsRange)
+	}
+
+	return filter
+}
+
+func NewAnalyticsUsecase(analyticsRepo domain.AnalyticsRepository) domain.AnalyticsUsecase {
+	return &analyticsUsecase{analyticsRepo: analyticsRepo, now: time.Now}
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/mocks"
+	"testing"
+	"time"
+)
+
+func Test_analyticsUsecase_TimeSeries(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	from := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter entity.AnalyticsFilter
+		want   entity.AnalyticsFilter
+	}{
+		{
+			name:   "default range",
+			filter: entity.AnalyticsFilter{ProjectId: 1},
+			want:   entity.AnalyticsFilter{ProjectId: 1, From: now.Add(-entity.DefaultAnalyticsRange), To: now},
+		},
+		{
+			name:   "default end",
+			filter: entity.AnalyticsFilter{ProjectId: 1, From: from},
+			want:   entity.AnalyticsFilter{ProjectId: 1, From: from, To: now},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyticsRepo := mocks.NewAnalyticsRepository(t)
+			analyticsRepo.On("TimeSeries", mock.Anything, tt.want).Return([]*entity.SeriesPoint{}, nil)
+
+			usecase := &analyticsUsecase{analyticsRepo: analyticsRepo, now: func() time.Time { return now }}
+			_, err := usecase.TimeSeries(context.Background(), tt.filter)
+			assert.NoError(t, err)
+		})
+	}
+}
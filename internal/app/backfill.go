@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	repository9 "goods-manager/internal/backfill/repository"
+	usecase9 "goods-manager/internal/backfill/usecase"
+	"goods-manager/internal/good/repository"
+	repository2 "goods-manager/internal/logger/repository"
+	"goods-manager/internal/transactor"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// BackfillConfig is configuration of backfill of the log
+type BackfillConfig struct {
+	// BatchSize is count of goods read and saved at once, zero is default
+	BatchSize int
+
+	// Restart starts backfill from the first good instead of the checkpoint
+	Restart bool
+}
+
+// RunBackfill writes snapshot events of all goods of database to ClickHouse until they are written, SIGINT or SIGTERM.
+// Interrupted backfill continues from the checkpoint by the next run.
+func RunBackfill(config BackfillConfig, db *sql.DB, clickhouse driver.Conn, logger *slog.Logger) error {
+	newTransactor := transactor.NewTransactor(db)
+
+	backfillUsecase := usecase9.NewBackfillUsecase(
+		repository.NewGoodRepository(newTransactor),
+		repository2.NewLoggerRepository(clickhouse),
+		repository9.NewCheckpointRepository(newTransactor),
+		config.BatchSize,
+		logger,
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	_, err := backfillUsecase.Run(ctx, config.Restart)
+	return err
+}
@@ -3,9 +3,9 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
-	"log"
 )
 
 // ConnectToClickHouse connect to clickhouse with default settings
@@ -24,7 +24,7 @@ func ConnectToClickHouse(addr string) (driver.Conn, error) {
 	if err := conn.Ping(ctx); err != nil {
 		var exception *clickhouse.Exception
 		if errors.As(err, &exception) {
-			log.Printf("Exception [%d] %s \n%s\n", exception.Code, exception.Message, exception.StackTrace)
+			return nil, fmt.Errorf("exception [%d] %s: %w", exception.Code, exception.Message, err)
 		}
 		return nil, err
 	}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	goodsv1 "goods-manager/api/goods/v1"
+	controller2 "goods-manager/internal/auth/controller"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/good/controller"
+	"goods-manager/internal/grpcerror"
+	controller3 "goods-manager/internal/project/controller"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"log/slog"
+)
+
+// newGRPCServer creates gRPC server of goods and projects services.
+//
+// Every call is authenticated, errors are converted to statuses with details of domain errors.
+func newGRPCServer(goodUsecase domain.GoodUsecase, projectUsecase domain.ProjectUsecase, authUsecase domain.AuthUsecase, logger *slog.Logger) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcerror.UnaryServerInterceptor(logger), controller2.UnaryAuthenticate(authUsecase)),
+		grpc.ChainStreamInterceptor(grpcerror.StreamServerInterceptor(logger), controller2.StreamAuthenticate(authUsecase)),
+	)
+
+	goodsv1.RegisterGoodsServiceServer(server, controller.NewGoodGRPCController(goodUsecase))
+	goodsv1.RegisterProjectsServiceServer(server, controller3.NewProjectGRPCController(projectUsecase))
+
+	// services are discoverable by tools like grpcurl
+	reflection.Register(server)
+
+	return server
+}
@@ -1,26 +1,121 @@
 package app
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 	"github.com/gin-gonic/gin"
 	"github.com/nats-io/nats.go"
+	"github.com/redis/go-redis/v9"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	controller7 "goods-manager/internal/analytics/controller"
+	repository8 "goods-manager/internal/analytics/repository"
+	usecase8 "goods-manager/internal/analytics/usecase"
+	controller2 "goods-manager/internal/auth/controller"
+	repository3 "goods-manager/internal/auth/repository"
+	usecase3 "goods-manager/internal/auth/usecase"
 	"goods-manager/internal/cache"
+	controller5 "goods-manager/internal/deadletter/controller"
+	repository7 "goods-manager/internal/deadletter/repository"
+	usecase7 "goods-manager/internal/deadletter/usecase"
+	"goods-manager/internal/domain/entity"
 	"goods-manager/internal/good/controller"
 	"goods-manager/internal/good/repository"
 	"goods-manager/internal/good/usecase"
+	"goods-manager/internal/httperror"
+	"goods-manager/internal/idempotency"
+	controller6 "goods-manager/internal/logger/controller"
 	repository2 "goods-manager/internal/logger/repository"
 	usecase2 "goods-manager/internal/logger/usecase"
-	"goods-manager/internal/logger/workers"
+	"goods-manager/internal/logging"
+	repository6 "goods-manager/internal/outbox/repository"
+	usecase6 "goods-manager/internal/outbox/usecase"
+	workers3 "goods-manager/internal/outbox/workers"
+	repository4 "goods-manager/internal/project/repository"
+	usecase4 "goods-manager/internal/project/usecase"
+	"goods-manager/internal/ratelimit"
+	"goods-manager/internal/stream"
+	"goods-manager/internal/tracing"
 	"goods-manager/internal/transactor"
-	"log"
+	"goods-manager/internal/validation"
+	controller4 "goods-manager/internal/webhook/controller"
+	repository5 "goods-manager/internal/webhook/repository"
+	usecase5 "goods-manager/internal/webhook/usecase"
+	workers2 "goods-manager/internal/webhook/workers"
+	"google.golang.org/grpc"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	_ "goods-manager/internal/docs"
 )
 
-// RunHTTPServe run HTTP server at `address`
+// v1DeprecatedAt is date of deprecation of v1 goods routes
+var v1DeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+const (
+	// webhookDispatchInterval is interval of polling of due webhook deliveries
+	webhookDispatchInterval = 1 * time.Second
+
+	// webhookDispatchBatch is max count of webhook deliveries sent concurrently
+	webhookDispatchBatch = 20
+
+	// outboxRelayInterval is interval of polling of pending outbox messages
+	outboxRelayInterval = 100 * time.Millisecond
+
+	// outboxRelayBatch is max count of outbox messages published at once
+	outboxRelayBatch = 100
+
+	// outboxCleanupInterval is interval of deletion of sent outbox messages
+	outboxCleanupInterval = 1 * time.Minute
+
+	// outboxCleanupBatch is max count of outbox messages deleted at once
+	outboxCleanupBatch = 1000
+
+	// shutdownTimeout is max time of waiting for active requests when server stops
+	shutdownTimeout = 30 * time.Second
+)
+
+// HTTPConfig is configuration of HTTP server
+type HTTPConfig struct {
+	// Address to listen, e.g. `:8080`
+	Address string
+
+	// AdminKey is API key with admin role in all projects.
+	// Empty value disables admin access.
+	AdminKey string
+
+	// JWT is configuration of bearer tokens
+	JWT usecase3.JWTConfig
+
+	// RateLimit is configuration of requests limits per client and route
+	RateLimit ratelimit.Config
+
+	// IdempotencyWindow is time while responses of idempotency keys are stored
+	IdempotencyWindow time.Duration
+
+	// Webhook is configuration of webhook deliveries
+	Webhook usecase5.Config
+
+	// GRPCAddress is address to listen by gRPC server, e.g. `:9090`.
+	// Empty value disables gRPC server.
+	GRPCAddress string
+
+	// Stream is configuration of Server-Sent Events of goods changes
+	Stream stream.Config
+
+	// Logger is configuration of logger pipeline
+	Logger LoggerConfig
+}
+
+// RunHTTPServe run HTTP server at `config.Address` until SIGINT or SIGTERM, then it stops gracefully
 //
 // @title			Goods manager
 // @version		1.0
@@ -28,12 +123,34 @@ import (
 //
 // @host		localhost:8080
 // @BasePath	/
-func RunHTTPServe(address string, db *sql.DB, cache cache.Cache, nats *nats.Conn, clickhouse driver.Conn) error {
+//
+// @securityDefinitions.apikey	ApiKeyAuth
+// @in							header
+// @name						X-API-Key
+//
+// @securityDefinitions.apikey	BearerAuth
+// @in							header
+// @name						Authorization
+// @description				JWT with `Bearer ` prefix
+func RunHTTPServe(config HTTPConfig, db *sql.DB, redisClient *redis.Client, cache cache.Cache, nats *nats.Conn, clickhouse driver.Conn, logger *slog.Logger) error {
+	gin.DebugPrintRouteFunc = func(httpMethod, absolutePath, handlerName string, _ int) {
+		logger.Debug("route registered", slog.String("method", httpMethod), slog.String("path", absolutePath), slog.String("handler", handlerName))
+	}
+
 	r := gin.New()
+	// handlers pass `*gin.Context` as context, so it must see values of request context (e.g. span, request ID)
+	r.ContextWithFallback = true
 
 	// Init middleware
-	r.Use(gin.Logger())
-	r.Use(gin.Recovery())
+	r.Use(tracing.Middleware())
+	r.Use(logging.RequestID())
+	r.Use(logging.AccessLog(logger))
+	r.Use(httperror.Middleware(logger))
+	r.Use(logging.Recovery(logger))
+
+	if err := validation.Register(); err != nil {
+		return fmt.Errorf("failed register validation rules: %w", err)
+	}
 
 	// Init newTransactor
 	newTransactor := transactor.NewTransactor(db)
@@ -44,32 +161,229 @@ func RunHTTPServe(address string, db *sql.DB, cache cache.Cache, nats *nats.Conn
 
 	loggerRepo := repository2.NewLoggerRepository(clickhouse)
 
+	analyticsRepo := repository8.NewAnalyticsRepository(clickhouse)
+
+	apiKeyRepo := repository3.NewAPIKeyRepository(newTransactor)
+
+	projectRepo := repository4.NewProjectRepository(newTransactor)
+
+	webhookRepo := repository5.NewWebhookRepository(newTransactor)
+
+	outboxRepo := repository6.NewOutboxRepository(newTransactor)
+
+	deadLetterRepo := repository7.NewDeadLetterRepository(newTransactor)
+
 	// Init usecase layer
-	loggerUsecase := usecase2.NewLoggerUsecase(nats, loggerRepo)
+	// logger consumes durable stream in JetStream mode, so outbox publishes to it
+	js, err := newJetStream(config.Logger, nats)
+	if err != nil {
+		return err
+	}
+
+	outboxUsecase := usecase6.NewOutboxUsecase(outboxRepo, nats, js, logger)
+
+	loggerUsecase := usecase2.NewLoggerUsecase(outboxUsecase, loggerRepo)
+
+	analyticsUsecase := usecase8.NewAnalyticsUsecase(analyticsRepo)
+
+	deadLetterUsecase := usecase7.NewDeadLetterUsecase(deadLetterRepo, outboxUsecase, newTransactor, logger)
+
+	webhookUsecase := usecase5.NewWebhookUsecase(webhookRepo, config.Webhook, logger)
+
+	goodUsecase := usecase.NewGoodUsecase(goodRepoCache, loggerUsecase, webhookUsecase, newTransactor, logger)
+
+	projectUsecase := usecase4.NewProjectUsecase(projectRepo, logger)
+
+	authUsecase, err := usecase3.NewAuthUsecase(apiKeyRepo, config.AdminKey, config.JWT, logger)
+	if err != nil {
+		return fmt.Errorf("failed init auth: %w", err)
+	}
+
+	// in-memory limiter is used while redis is unavailable
+	limiter := ratelimit.NewFallbackLimiter(ratelimit.NewRedisLimiter(redisClient, "ratelimit:"), ratelimit.NewMemoryLimiter(), logger)
+	rateLimit := ratelimit.Middleware(limiter, config.RateLimit, logger)
+	ipLimit := ratelimit.IPMiddleware(limiter, config.RateLimit, logger)
 
-	goodUsecase := usecase.NewGoodUsecase(goodRepoCache, loggerUsecase, newTransactor)
+	idempotent := idempotency.Middleware(cache, config.IdempotencyWindow, logger)
 
 	// Init controller layer
 	goodController := controller.NewGoodController(goodUsecase)
+	goodControllerV2 := controller.NewGoodControllerV2(goodUsecase)
+
+	authController := controller2.NewAuthController(authUsecase)
+
+	webhookController := controller4.NewWebhookController(webhookUsecase)
+
+	deadLetterController := controller5.NewDeadLetterController(deadLetterUsecase)
+
+	historyController := controller6.NewHistoryController(loggerUsecase)
+
+	analyticsController := controller7.NewAnalyticsController(analyticsUsecase)
+
+	streamHub := stream.NewHub(nats, config.Stream, logger)
 
 	// Add route
-	goodR := r.Group("/good")
+	// v1 routes are kept for existing clients
+	goodR := r.Group("/good", controller.Deprecated(v1DeprecatedAt, "/v2/projects/{projectId}/goods"), ipLimit, controller2.Authenticate(authUsecase), rateLimit)
+
+	goodR.POST("/create", controller2.RequireRole(entity.RoleEditor), idempotent, goodController.Create)
+	goodR.GET("/list", controller2.RequireRole(entity.RoleViewer), goodController.List)
+	goodR.PATCH("/update", controller2.RequireRole(entity.RoleEditor), idempotent, goodController.Update)
+	goodR.DELETE("/remove", controller2.RequireRole(entity.RoleAdmin), idempotent, goodController.Delete)
+
+	goodR.PATCH("/reprioritiize", controller2.RequireRole(entity.RoleEditor), idempotent, goodController.Reprioritize)
+
+	goodV2R := r.Group("/v2/projects/:projectId/goods", ipLimit, controller2.Authenticate(authUsecase), rateLimit)
+
+	goodV2R.POST("", controller2.RequireRole(entity.RoleEditor), idempotent, goodControllerV2.Create)
+	goodV2R.GET("", controller2.RequireRole(entity.RoleViewer), goodControllerV2.List)
+	goodV2R.GET("/batch", controller2.RequireRole(entity.RoleViewer), goodControllerV2.BatchGet)
+	goodV2R.GET("/events", controller2.RequireRole(entity.RoleViewer), stream.Handler(streamHub, config.Stream.Heartbeat))
+	goodV2R.GET("/history", controller2.RequireRole(entity.RoleViewer), historyController.ProjectHistory)
+	goodV2R.GET("/:id", controller2.RequireRole(entity.RoleViewer), goodControllerV2.Get)
+	goodV2R.PUT("/:id", controller2.RequireRole(entity.RoleEditor), idempotent, goodControllerV2.Replace)
+	goodV2R.PATCH("/:id", controller2.RequireRole(entity.RoleEditor), idempotent, goodControllerV2.Patch)
+	goodV2R.DELETE("/:id", controller2.RequireRole(entity.RoleAdmin), idempotent, goodControllerV2.Delete)
+	goodV2R.POST("/:id/move", controller2.RequireRole(entity.RoleEditor), idempotent, goodControllerV2.Move)
+	goodV2R.GET("/:id/history", controller2.RequireRole(entity.RoleViewer), historyController.GoodHistory)
+
+	analyticsR := r.Group("/v2/projects/:projectId/analytics", ipLimit, controller2.Authenticate(authUsecase), rateLimit, controller2.RequireRole(entity.RoleViewer))
 
-	goodR.POST("/create", goodController.Create)
-	goodR.GET("/list", goodController.List)
-	goodR.PATCH("/update", goodController.Update)
-	goodR.DELETE("/remove", goodController.Delete)
+	analyticsR.GET("/events", analyticsController.TimeSeries)
+	analyticsR.GET("/top-goods", analyticsController.TopGoods)
 
-	goodR.PATCH("/reprioritiize", goodController.Reprioritize)
+	webhookR := r.Group("/v2/projects/:projectId/webhooks", ipLimit, controller2.Authenticate(authUsecase), rateLimit, controller2.RequireRole(entity.RoleAdmin))
+
+	webhookR.POST("", idempotent, webhookController.Create)
+	webhookR.GET("", webhookController.List)
+	webhookR.GET("/:id", webhookController.Get)
+	webhookR.PATCH("/:id", webhookController.Patch)
+	webhookR.DELETE("/:id", webhookController.Delete)
+	webhookR.GET("/:id/deliveries", webhookController.Deliveries)
+
+	deadLetterR := r.Group("/v2/admin/dead-letters", ipLimit, controller2.Authenticate(authUsecase), rateLimit, controller2.RequireAdmin())
+
+	deadLetterR.GET("", deadLetterController.List)
+	deadLetterR.GET("/:id", deadLetterController.Get)
+	deadLetterR.POST("/:id/replay", idempotent, deadLetterController.Replay)
+
+	authR := r.Group("/auth/key", ipLimit, controller2.Authenticate(authUsecase), rateLimit, controller2.RequireAdmin())
+
+	authR.POST("/create", authController.CreateKey)
+	authR.GET("/list", authController.ListKeys)
+	authR.DELETE("/remove", authController.RevokeKey)
 
 	// Init swagger doc
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	log.Println("starting logger worker...")
-	if err := workers.NewLoggerWorker(nats, loggerUsecase).Run(); err != nil {
-		log.Panicln("failed start logger worker", err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// workers are stopped after servers, so changes of the last requests are handled,
+	// server returns when workers stop and logger worker saves received events
+	workersCtx, cancelWorkers := context.WithCancel(context.Background())
+	var waits []func()
+	defer func() {
+		cancelWorkers()
+		for _, wait := range waits {
+			wait()
+		}
+	}()
+
+	if !config.Logger.Standalone {
+		wait, err := startLoggerWorker(workersCtx, config.Logger, nats, js, loggerUsecase, deadLetterUsecase, logger)
+		if err != nil {
+			return err
+		}
+		waits = append(waits, wait)
+	}
+
+	logger.Info("starting change stream...")
+	if err := streamHub.Run(); err != nil {
+		return fmt.Errorf("failed start change stream: %w", err)
+	}
+	defer streamHub.Close()
+
+	logger.Info("starting outbox relay...")
+	waits = append(waits, goWorker(workersCtx, workers3.NewRelayWorker(outboxUsecase, outboxRelayInterval, outboxRelayBatch, logger).Run))
+	waits = append(waits, goWorker(workersCtx, workers3.NewCleanupWorker(outboxUsecase, outboxCleanupInterval, outboxCleanupBatch, logger).Run))
+
+	logger.Info("starting webhook dispatcher...")
+	waits = append(waits, goWorker(workersCtx, workers2.NewDispatcherWorker(webhookUsecase, webhookDispatchInterval, webhookDispatchBatch, logger).Run))
+
+	// both servers run until one of them fails or signal is received
+	errs := make(chan error, 2)
+
+	var grpcServer *grpc.Server
+	if config.GRPCAddress != "" {
+		listener, err := net.Listen("tcp", config.GRPCAddress)
+		if err != nil {
+			return fmt.Errorf("failed listen gRPC address: %w", err)
+		}
+
+		grpcServer = newGRPCServer(goodUsecase, projectUsecase, authUsecase, logger)
+
+		logger.Info("starting gRPC server...", slog.String("address", config.GRPCAddress))
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				errs <- fmt.Errorf("gRPC server stopped: %w", err)
+			}
+		}()
 	}
 
-	log.Println("starting server...")
-	return r.Run(address)
+	server := &http.Server{Addr: config.Address, Handler: r}
+	// subscribers of change stream are disconnected, so their requests don't block shutdown
+	server.RegisterOnShutdown(func() { _ = streamHub.Close() })
+
+	logger.Info("starting server...", slog.String("address", config.Address))
+	go func() {
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			errs <- err
+		}
+	}()
+
+	var serveErr error
+	select {
+	case serveErr = <-errs:
+	case <-ctx.Done():
+		logger.Info("stopping server...")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("failed shutdown server", slog.Any("error", err))
+	}
+	if grpcServer != nil {
+		stopGRPCServer(shutdownCtx, grpcServer)
+	}
+
+	return serveErr
+}
+
+// goWorker runs the worker in goroutine until context is done, returned function waits until it stops
+func goWorker(ctx context.Context, run func(ctx context.Context)) func() {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(ctx)
+	}()
+
+	return func() { <-done }
+}
+
+// stopGRPCServer waits until active RPCs finish, they are cancelled when context is done
+func stopGRPCServer(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		server.GracefulStop()
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
 }
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	repository7 "goods-manager/internal/deadletter/repository"
+	usecase7 "goods-manager/internal/deadletter/usecase"
+	"goods-manager/internal/domain"
+	repository2 "goods-manager/internal/logger/repository"
+	usecase2 "goods-manager/internal/logger/usecase"
+	"goods-manager/internal/logger/workers"
+	repository6 "goods-manager/internal/outbox/repository"
+	usecase6 "goods-manager/internal/outbox/usecase"
+	"goods-manager/internal/transactor"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// LoggerConfig is configuration of logger pipeline
+type LoggerConfig struct {
+	// Standalone is true if logger worker runs by separate process of `RunLogger`, so server doesn't run it
+	Standalone bool
+
+	// Worker is configuration of logger worker on core NATS
+	Worker workers.LoggerConfig
+
+	// JetStream is configuration of logger pipeline on NATS JetStream
+	JetStream workers.JetStreamConfig
+}
+
+// RunLogger runs logger worker without HTTP server until SIGINT or SIGTERM, so API and logger are scaled independently
+func RunLogger(config LoggerConfig, db *sql.DB, nats *nats.Conn, clickhouse driver.Conn, logger *slog.Logger) error {
+	newTransactor := transactor.NewTransactor(db)
+
+	js, err := newJetStream(config, nats)
+	if err != nil {
+		return err
+	}
+
+	outboxUsecase := usecase6.NewOutboxUsecase(repository6.NewOutboxRepository(newTransactor), nats, js, logger)
+	loggerUsecase := usecase2.NewLoggerUsecase(outboxUsecase, repository2.NewLoggerRepository(clickhouse))
+	deadLetterUsecase := usecase7.NewDeadLetterUsecase(repository7.NewDeadLetterRepository(newTransactor), outboxUsecase, newTransactor, logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	wait, err := startLoggerWorker(ctx, config, nats, js, loggerUsecase, deadLetterUsecase, logger)
+	if err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	logger.Info("stopping logger worker...")
+	wait()
+
+	return nil
+}
+
+// newJetStream returns JetStream of the connection if it is enabled, otherwise nil
+func newJetStream(config LoggerConfig, nats *nats.Conn) (jetstream.JetStream, error) {
+	if !config.JetStream.Enabled {
+		return nil, nil
+	}
+
+	js, err := jetstream.New(nats)
+	if err != nil {
+		return nil, fmt.Errorf("failed init JetStream: %w", err)
+	}
+
+	return js, nil
+}
+
+// startLoggerWorker starts JetStream worker if `js` isn't nil, otherwise core NATS worker.
+// Worker stops when context is done, returned function waits until it saves received events.
+func startLoggerWorker(ctx context.Context, config LoggerConfig, nats *nats.Conn, js jetstream.JetStream,
+	loggerUsecase domain.LoggerUsecase, deadLetterUsecase domain.DeadLetterUsecase, logger *slog.Logger) (func(), error) {
+	if js != nil {
+		logger.Info("starting JetStream logger worker...")
+		worker, err := workers.NewJetStreamWorker(ctx, js, config.JetStream, loggerUsecase, deadLetterUsecase, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed init logger stream: %w", err)
+		}
+
+		return goWorker(ctx, worker.Run), nil
+	}
+
+	logger.Info("starting logger worker...")
+	worker := workers.NewLoggerWorker(nats, config.Worker, loggerUsecase, deadLetterUsecase, logger)
+	if err := worker.Run(ctx); err != nil {
+		return nil, fmt.Errorf("failed start logger worker: %w", err)
+	}
+
+	return worker.Wait, nil
+}
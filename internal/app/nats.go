@@ -1,13 +1,18 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"log/slog"
 )
 
-// ConnectToNats connect to nats
-func ConnectToNats(url string) (*nats.Conn, error) {
-	conn, err := nats.Connect(url)
+// ConnectToNats connect to nats, async errors of the connection are logged
+func ConnectToNats(url string, logger *slog.Logger) (*nats.Conn, error) {
+	conn, err := nats.Connect(url, nats.ErrorHandler(asyncErrorHandler(logger)))
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +23,33 @@ func ConnectToNats(url string) (*nats.Conn, error) {
 
 	return conn, nil
 }
+
+// asyncErrorHandler logs async errors of NATS. Messages over pending limits of subscription are dropped
+// by NATS client, so every slow consumer is counted by `nats.slow_consumers` counter with subject.
+func asyncErrorHandler(logger *slog.Logger) nats.ErrHandler {
+	slowConsumers, err := otel.Meter("goods-manager/nats").Int64Counter("nats.slow_consumers",
+		metric.WithDescription("count of subscriptions whose messages were dropped by NATS client"))
+	if err != nil {
+		logger.Error("failed create counter of slow consumers", slog.Any("error", err))
+	}
+
+	return func(_ *nats.Conn, sub *nats.Subscription, err error) {
+		if sub == nil {
+			logger.Error("nats async error", slog.Any("error", err))
+			return
+		}
+
+		if !errors.Is(err, nats.ErrSlowConsumer) {
+			logger.Error("nats async error", slog.String("subject", sub.Subject), slog.Any("error", err))
+			return
+		}
+
+		// dropped is total count of dropped messages of the subscription, error means it is already closed
+		dropped, _ := sub.Dropped()
+		if slowConsumers != nil {
+			slowConsumers.Add(context.Background(), 1, metric.WithAttributes(attribute.String("subject", sub.Subject)))
+		}
+		logger.Error("nats dropped messages of slow consumer", slog.String("subject", sub.Subject),
+			slog.String("queue_group", sub.Queue), slog.Int("dropped", dropped))
+	}
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"os"
+)
+
+// Values of `OTEL_TRACES_EXPORTER`
+const (
+	TracesExporterNone    = "none"
+	TracesExporterConsole = "console"
+	TracesExporterOTLP    = "otlp"
+)
+
+// InitTracer configure global tracer provider and propagator.
+//
+// `exporter` is one of `TracesExporterNone`, `TracesExporterConsole` or `TracesExporterOTLP`,
+// empty value disables tracing. OTLP exporter (HTTP) is configured by
+// standard `OTEL_EXPORTER_OTLP_*` environment variables.
+// It returns function that flush and stop tracer provider.
+func InitTracer(ctx context.Context, serviceName, exporter string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case "", TracesExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case TracesExporterConsole:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	case TracesExporterOTLP:
+		spanExporter, err = otlptracehttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("unknown traces exporter %q", exporter)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// attributes from `OTEL_SERVICE_NAME` and `OTEL_RESOURCE_ATTRIBUTES` override default service name
+	res, err := resource.New(ctx,
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
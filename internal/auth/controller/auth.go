@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/httperror"
+	"goods-manager/internal/validation"
+	"strconv"
+)
+
+type AuthController struct {
+	authUsecase domain.AuthUsecase
+}
+
+// CreateKey this function is used to create API key.
+//
+// @Summary		Create API key scoped to projects
+// @Tags		auth
+// @Accept		json
+// @Produce		json
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+//
+// @Param		key		body		CreateKeyRequest	true	"Name and projects of the key"
+//
+// @Success		200		{object}	CreateKeyResponse	"Created key, plain key is returned only once"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Forbidden"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/auth/key/create 	[post]
+func (a *AuthController) CreateKey(c *gin.Context) {
+	var request CreateKeyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		httperror.Abort(c, validation.Error(err))
+		return
+	}
+
+	apiKey := &entity.APIKey{Name: request.Name, ProjectIds: request.ProjectIds, Role: request.Role}
+	key, err := a.authUsecase.CreateKey(c, apiKey)
+	if err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(200, CreateKeyResponse{APIKey: apiKey, Key: key})
+}
+
+// ListKeys this function is used for get API keys.
+//
+// @Summary		Get list of active API keys
+// @Tags		auth
+// @Produce		json
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+//
+// @Success		200		{object}	ListKeysResponse	"API keys without plain keys"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Forbidden"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/auth/key/list		[get]
+func (a *AuthController) ListKeys(c *gin.Context) {
+	keys, err := a.authUsecase.ListKeys(c)
+	if err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(200, ListKeysResponse{Keys: keys})
+}
+
+// RevokeKey this function revoke API key.
+//
+// @Summary		Revoke API key
+// @Tags		auth
+// @Produce		json
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+//
+// @Param		id		query		int					true	"ID of API key"
+//
+// @Success		200		{object}	RevokeKeyResponse	"Key was revoked"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Forbidden"
+// @Failure		404		{object}	httperror.Response	"Key not found"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/auth/key/remove	[delete]
+func (a *AuthController) RevokeKey(c *gin.Context) {
+	id, ok := c.GetQuery("id")
+	if !ok {
+		httperror.Abort(c, domain.NewFieldError("id", domain.FieldRequired, "id is required"))
+		return
+	}
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		httperror.Abort(c, domain.NewFieldError("id", domain.FieldInvalid, "id must be integer"))
+		return
+	}
+
+	if err := a.authUsecase.RevokeKey(c, idInt); err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(200, RevokeKeyResponse{Id: idInt, Revoked: true})
+}
+
+func NewAuthController(authUsecase domain.AuthUsecase) *AuthController {
+	return &AuthController{authUsecase: authUsecase}
+}
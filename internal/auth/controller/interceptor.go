@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"goods-manager/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strings"
+)
+
+// UnaryAuthenticate is gRPC equivalent of `Authenticate`.
+//
+// Credentials are taken from `authorization` or `x-api-key` metadata, principal is stored in context of the call.
+func UnaryAuthenticate(authUsecase domain.AuthUsecase) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticateCall(ctx, authUsecase)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthenticate is `UnaryAuthenticate` for streaming calls
+func StreamAuthenticate(authUsecase domain.AuthUsecase) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticateCall(stream.Context(), authUsecase)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticateCall returns context of the call with principal
+func authenticateCall(ctx context.Context, authUsecase domain.AuthUsecase) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+
+		return ""
+	}
+
+	principal, err := authenticate(ctx, authUsecase, first("authorization"), first(strings.ToLower(APIKeyHeader)))
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.WithPrincipal(ctx, principal), nil
+}
+
+// authenticatedStream is stream with context of authenticated call
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/httperror"
+	"strconv"
+	"strings"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+
+	bearerPrefix = "Bearer "
+)
+
+// Authenticate resolves credentials to principal and stores it in request context.
+//
+// Bearer token from `Authorization` header is used if it is present, otherwise API key from `APIKeyHeader`.
+// Request without valid credentials is aborted with 401.
+func Authenticate(authUsecase domain.AuthUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := authenticate(c.Request.Context(), authUsecase, c.GetHeader("Authorization"), c.GetHeader(APIKeyHeader))
+		if err != nil {
+			httperror.Abort(c, err)
+			return
+		}
+
+		c.Request = c.Request.WithContext(domain.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// authenticate resolves bearer token from `authorization` if it is present, otherwise API key
+func authenticate(ctx context.Context, authUsecase domain.AuthUsecase, authorization, key string) (*entity.Principal, error) {
+	switch {
+	case authorization != "":
+		if !strings.HasPrefix(authorization, bearerPrefix) {
+			return nil, domain.NewUnauthorizedError("bearer token is required")
+		}
+
+		return authUsecase.AuthenticateToken(ctx, strings.TrimPrefix(authorization, bearerPrefix))
+	case key != "":
+		return authUsecase.Authenticate(ctx, key)
+	default:
+		return nil, domain.NewUnauthorizedError("api key or bearer token is required")
+	}
+}
+
+// RequireRole checks that principal has `role` in the project
+// from `projectId` path parameter or, if route has no such parameter, from `projectId` query parameter.
+//
+// It must be used after `Authenticate`.
+func RequireRole(role entity.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectId, ok := c.Params.Get("projectId")
+		if !ok {
+			projectId, ok = c.GetQuery("projectId")
+		}
+		if !ok {
+			httperror.Abort(c, domain.NewFieldError("projectId", domain.FieldRequired, "projectId is required"))
+			return
+		}
+		projectIdInt, err := strconv.Atoi(projectId)
+		if err != nil {
+			httperror.Abort(c, domain.NewFieldError("projectId", domain.FieldInvalid, "projectId must be integer"))
+			return
+		}
+
+		if err := domain.Authorize(c.Request.Context(), projectIdInt, role); err != nil {
+			httperror.Abort(c, err)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireAdmin allows request only for principal with admin role in all projects.
+//
+// It must be used after `Authenticate`.
+func RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := domain.AuthorizeAdmin(c.Request.Context()); err != nil {
+			httperror.Abort(c, err)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/httperror"
+	"goods-manager/mocks"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthenticateRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authUsecase := mocks.NewAuthUsecase(t)
+	authUsecase.On("Authenticate", mock.Anything, "scoped").
+		Return(&entity.Principal{Subject: "api_key:1", Roles: map[int]entity.Role{1: entity.RoleEditor}}, nil).Maybe()
+	authUsecase.On("Authenticate", mock.Anything, "invalid").
+		Return(nil, domain.ErrorUnauthorized).Maybe()
+	authUsecase.On("AuthenticateToken", mock.Anything, "viewer-token").
+		Return(&entity.Principal{Subject: "user:1", Roles: map[int]entity.Role{1: entity.RoleViewer}}, nil).Maybe()
+
+	r := gin.New()
+	r.Use(httperror.Middleware(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	r.PATCH("/good/reprioritiize", Authenticate(authUsecase), RequireRole(entity.RoleEditor), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name          string
+		key           string
+		authorization string
+		query         string
+		status        int
+	}{
+		{name: "without credentials", query: "?projectId=1", status: http.StatusUnauthorized},
+		{name: "invalid key", key: "invalid", query: "?projectId=1", status: http.StatusUnauthorized},
+		{name: "not bearer authorization", authorization: "Basic dXNlcjpwYXNz", query: "?projectId=1", status: http.StatusUnauthorized},
+		{name: "without project", key: "scoped", status: http.StatusBadRequest},
+		{name: "project out of scope", key: "scoped", query: "?projectId=2", status: http.StatusForbidden},
+		{name: "role is not enough", authorization: "Bearer viewer-token", query: "?projectId=1", status: http.StatusForbidden},
+		{name: "editor in project", key: "scoped", query: "?projectId=1", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/good/reprioritiize"+tt.query, nil)
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestRequireRoleProjectFromPath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authUsecase := mocks.NewAuthUsecase(t)
+	authUsecase.On("Authenticate", mock.Anything, "scoped").
+		Return(&entity.Principal{Subject: "api_key:1", Roles: map[int]entity.Role{1: entity.RoleEditor}}, nil)
+
+	r := gin.New()
+	r.Use(httperror.Middleware(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	r.POST("/v2/projects/:projectId/goods", Authenticate(authUsecase), RequireRole(entity.RoleEditor), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{name: "project in scope", path: "/v2/projects/1/goods", status: http.StatusOK},
+		{name: "path has priority over query", path: "/v2/projects/2/goods?projectId=1", status: http.StatusForbidden},
+		{name: "invalid project", path: "/v2/projects/abc/goods", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			req.Header.Set(APIKeyHeader, "scoped")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
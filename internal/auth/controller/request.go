@@ -0,0 +1,11 @@
+package controller
+
+import "goods-manager/internal/domain/entity"
+
+type CreateKeyRequest struct {
+	Name       string `json:"name" binding:"required,notblank,max=255,singleline" minLength:"1" maxLength:"255"`
+	ProjectIds []int  `json:"project_ids" binding:"required,min=1,dive,min=1" minItems:"1"`
+
+	// Role of the key in its projects, default is editor
+	Role entity.Role `json:"role" binding:"omitempty,oneof=viewer editor admin" enums:"viewer,editor,admin"`
+}
@@ -0,0 +1,19 @@
+package controller
+
+import "goods-manager/internal/domain/entity"
+
+type CreateKeyResponse struct {
+	*entity.APIKey
+
+	// Key is plain API key, it is returned only once
+	Key string `json:"key"`
+}
+
+type ListKeysResponse struct {
+	Keys []*entity.APIKey `json:"keys"`
+}
+
+type RevokeKeyResponse struct {
+	Id      int  `json:"id"`
+	Revoked bool `json:"revoked"`
+}
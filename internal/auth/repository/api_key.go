@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
+	"goods-manager/internal/transactor"
+)
+
+type apiKeyRepository struct {
+	transactor *transactor.Transactor
+}
+
+// Create inserts API key and sets its ID and creation timestamp
+func (a *apiKeyRepository) Create(ctx context.Context, apiKey *entity.APIKey) (err error) {
+	ctx, span := tracing.Start(ctx, "apiKeyRepository.Create")
+	defer tracing.End(span, &err)
+
+	query := `
+		INSERT INTO api_keys (name, prefix, key_hash, project_ids, role)
+			VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, revoked, created_at
+	`
+
+	tx, db := a.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, apiKey.Name, apiKey.Prefix, apiKey.Hash, pq.Array(apiKey.ProjectIds), apiKey.Role)
+	} else {
+		row = db.QueryRowContext(ctx, query, apiKey.Name, apiKey.Prefix, apiKey.Hash, pq.Array(apiKey.ProjectIds), apiKey.Role)
+	}
+
+	return row.Scan(&apiKey.Id, &apiKey.Revoked, &apiKey.CreatedAt)
+}
+
+// GetByHash gets active API key by hash of the key
+func (a *apiKeyRepository) GetByHash(ctx context.Context, hash string) (_ *entity.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "apiKeyRepository.GetByHash")
+	defer tracing.End(span, &err)
+
+	query := `
+		SELECT id, name, prefix, key_hash, project_ids, role, revoked, created_at FROM api_keys
+			WHERE key_hash = $1 AND revoked = false
+	`
+
+	tx, db := a.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, hash)
+	} else {
+		row = db.QueryRowContext(ctx, query, hash)
+	}
+
+	apiKey, err := scanAPIKey(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrorAPIKeyNotFound
+		}
+
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+// List gets all active API keys
+func (a *apiKeyRepository) List(ctx context.Context) (_ []*entity.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "apiKeyRepository.List")
+	defer tracing.End(span, &err)
+
+	query := `
+		SELECT id, name, prefix, key_hash, project_ids, role, revoked, created_at FROM api_keys
+			WHERE revoked = false
+			ORDER BY id
+	`
+
+	tx, db := a.transactor.Connection(ctx)
+	var rows *sql.Rows
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query)
+	} else {
+		rows, err = db.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed close rows: %w", closeErr))
+		}
+	}()
+
+	apiKeys := make([]*entity.APIKey, 0)
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+// Revoke marks API key as revoked.
+// It returns `domain.ErrorAPIKeyNotFound` if there is no active key with the ID.
+func (a *apiKeyRepository) Revoke(ctx context.Context, id int) (err error) {
+	ctx, span := tracing.Start(ctx, "apiKeyRepository.Revoke")
+	defer tracing.End(span, &err)
+
+	query := `UPDATE api_keys SET revoked = true WHERE id = $1 AND revoked = false`
+
+	tx, db := a.transactor.Connection(ctx)
+	var res sql.Result
+	if tx != nil {
+		res, err = tx.ExecContext(ctx, query, id)
+	} else {
+		res, err = db.ExecContext(ctx, query, id)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrorAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// scanAPIKey scans row selected with columns
+// id, name, prefix, key_hash, project_ids, role, revoked, created_at
+func scanAPIKey(row interface{ Scan(dest ...any) error }) (*entity.APIKey, error) {
+	var apiKey entity.APIKey
+	var projectIds pq.Int64Array
+	err := row.Scan(&apiKey.Id, &apiKey.Name, &apiKey.Prefix, &apiKey.Hash, &projectIds, &apiKey.Role, &apiKey.Revoked, &apiKey.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey.ProjectIds = make([]int, 0, len(projectIds))
+	for _, id := range projectIds {
+		apiKey.ProjectIds = append(apiKey.ProjectIds, int(id))
+	}
+
+	return &apiKey, nil
+}
+
+func NewAPIKeyRepository(transactor *transactor.Transactor) domain.APIKeyRepository {
+	return &apiKeyRepository{transactor: transactor}
+}
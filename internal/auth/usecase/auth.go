@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
+	"log/slog"
+	"strconv"
+)
+
+const (
+	// keyPrefix is a prefix of generated keys, it helps to find leaked keys
+	keyPrefix = "gm_"
+
+	// keyBytes is count of random bytes in generated key
+	keyBytes = 32
+
+	// displayPrefixLength is length of key part stored as is to identify the key
+	displayPrefixLength = 8
+
+	AdminSubject = "admin"
+
+	// minAdminKeyLength is min length of admin key from configuration
+	minAdminKeyLength = 16
+)
+
+// exampleAdminKeys are admin keys published in examples of configuration
+var exampleAdminKeys = []string{"admin-secret"}
+
+// authUsecase implementation `domain.AuthUsecase`.
+//
+// Keys are stored as SHA-256 hash, it is enough because keys have high entropy.
+// Admin key from configuration gives admin role in all projects.
+// Bearer tokens are verified by `tokenVerifier`, it is nil if tokens are disabled.
+type authUsecase struct {
+	apiKeyRepo    domain.APIKeyRepository
+	adminKeyHash  string
+	tokenVerifier *tokenVerifier
+	logger        *slog.Logger
+}
+
+func (a *authUsecase) Authenticate(ctx context.Context, key string) (_ *entity.Principal, err error) {
+	ctx, span := tracing.Start(ctx, "authUsecase.Authenticate")
+	defer tracing.End(span, &err)
+
+	if key == "" {
+		return nil, domain.ErrorUnauthorized
+	}
+
+	hash := HashKey(key)
+	if a.adminKeyHash != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(a.adminKeyHash)) == 1 {
+		return &entity.Principal{Subject: AdminSubject, AllProjectsRole: entity.RoleAdmin}, nil
+	}
+
+	apiKey, err := a.apiKeyRepo.GetByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, domain.ErrorAPIKeyNotFound) {
+			return nil, domain.ErrorUnauthorized
+		}
+
+		return nil, err
+	}
+
+	roles := make(map[int]entity.Role, len(apiKey.ProjectIds))
+	for _, projectId := range apiKey.ProjectIds {
+		roles[projectId] = apiKey.Role
+	}
+
+	return &entity.Principal{Subject: "api_key:" + strconv.Itoa(apiKey.Id), Roles: roles}, nil
+}
+
+func (a *authUsecase) AuthenticateToken(ctx context.Context, token string) (_ *entity.Principal, err error) {
+	ctx, span := tracing.Start(ctx, "authUsecase.AuthenticateToken")
+	defer tracing.End(span, &err)
+
+	if token == "" || a.tokenVerifier == nil {
+		return nil, domain.ErrorUnauthorized
+	}
+
+	principal, err := a.tokenVerifier.Verify(token)
+	if err != nil {
+		a.logger.DebugContext(ctx, "invalid bearer token", slog.Any("error", err))
+		return nil, domain.ErrorUnauthorized
+	}
+
+	return principal, nil
+}
+
+// CreateKey generate key and save its hash
+func (a *authUsecase) CreateKey(ctx context.Context, apiKey *entity.APIKey) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "authUsecase.CreateKey")
+	defer tracing.End(span, &err)
+
+	if apiKey.Role == "" {
+		apiKey.Role = entity.RoleEditor
+	}
+
+	var fields []domain.FieldError
+	if apiKey.Name == "" {
+		fields = append(fields, domain.FieldError{Field: "name", Code: domain.FieldRequired, Message: "name is required"})
+	}
+	if len(apiKey.ProjectIds) == 0 {
+		fields = append(fields, domain.FieldError{Field: "project_ids", Code: domain.FieldRequired, Message: "project_ids is required"})
+	}
+	for _, projectId := range apiKey.ProjectIds {
+		if projectId < 1 {
+			fields = append(fields, domain.FieldError{Field: "project_ids", Code: domain.FieldInvalid, Message: "project_ids must be greater than 0"})
+			break
+		}
+	}
+	if !apiKey.Role.Valid() {
+		fields = append(fields, domain.FieldError{Field: "role", Code: domain.FieldInvalid, Message: "role must be one of viewer, editor, admin"})
+	}
+	if len(fields) > 0 {
+		return "", domain.NewValidationError("invalid api key", fields...)
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		return "", err
+	}
+
+	apiKey.Prefix = key[:displayPrefixLength]
+	apiKey.Hash = HashKey(key)
+
+	if err := a.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return "", err
+	}
+
+	a.logger.InfoContext(ctx, "api key created", slog.Int("id", apiKey.Id), slog.Any("project_ids", apiKey.ProjectIds), slog.String("role", string(apiKey.Role)))
+	return key, nil
+}
+
+func (a *authUsecase) ListKeys(ctx context.Context) (_ []*entity.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "authUsecase.ListKeys")
+	defer tracing.End(span, &err)
+
+	return a.apiKeyRepo.List(ctx)
+}
+
+func (a *authUsecase) RevokeKey(ctx context.Context, id int) (err error) {
+	ctx, span := tracing.Start(ctx, "authUsecase.RevokeKey")
+	defer tracing.End(span, &err)
+
+	if err := a.apiKeyRepo.Revoke(ctx, id); err != nil {
+		return err
+	}
+
+	a.logger.InfoContext(ctx, "api key revoked", slog.Int("id", id))
+	return nil
+}
+
+// HashKey returns hex encoded SHA-256 hash of the key
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateKey returns new random key with `keyPrefix`
+func generateKey() (string, error) {
+	b := make([]byte, keyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// validateAdminKey rejects admin keys which are short or published as examples
+func validateAdminKey(adminKey string) error {
+	if len(adminKey) < minAdminKeyLength {
+		return fmt.Errorf("admin key must have at least %d characters", minAdminKeyLength)
+	}
+
+	for _, example := range exampleAdminKeys {
+		if adminKey == example {
+			return errors.New("admin key is an example value, generate a random one")
+		}
+	}
+
+	return nil
+}
+
+// NewAuthUsecase creates auth usecase. Empty `adminKey` disables admin access.
+// It returns error if admin key is weak or keys of bearer tokens can't be loaded.
+func NewAuthUsecase(apiKeyRepo domain.APIKeyRepository, adminKey string, jwtConfig JWTConfig, logger *slog.Logger) (domain.AuthUsecase, error) {
+	var adminKeyHash string
+	if adminKey != "" {
+		if err := validateAdminKey(adminKey); err != nil {
+			return nil, err
+		}
+		adminKeyHash = HashKey(adminKey)
+	} else {
+		logger.Warn("admin key isn't set, admin access is disabled")
+	}
+
+	verifier, err := newTokenVerifier(jwtConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &authUsecase{apiKeyRepo: apiKeyRepo, adminKeyHash: adminKeyHash, tokenVerifier: verifier, logger: logger}, nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/mocks"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func newTestUsecase(t *testing.T) (domain.AuthUsecase, *mocks.APIKeyRepository) {
+	repo := mocks.NewAPIKeyRepository(t)
+	usecase, err := NewAuthUsecase(repo, "admin-key-for-tests-only", JWTConfig{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return usecase, repo
+}
+
+func Test_authUsecase_Authenticate(t *testing.T) {
+	usecase, repo := newTestUsecase(t)
+	ctx := context.Background()
+
+	repo.On("GetByHash", mock.Anything, HashKey("gm_valid")).
+		Return(&entity.APIKey{Id: 7, ProjectIds: []int{1, 2}, Role: entity.RoleViewer}, nil)
+	repo.On("GetByHash", mock.Anything, HashKey("gm_unknown")).
+		Return(nil, domain.ErrorAPIKeyNotFound)
+
+	principal, err := usecase.Authenticate(ctx, "gm_valid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &entity.Principal{
+		Subject: "api_key:7",
+		Roles:   map[int]entity.Role{1: entity.RoleViewer, 2: entity.RoleViewer},
+	}, principal)
+
+	_, err = usecase.Authenticate(ctx, "gm_unknown")
+	assert.ErrorIs(t, err, domain.ErrorUnauthorized)
+
+	_, err = usecase.Authenticate(ctx, "")
+	assert.ErrorIs(t, err, domain.ErrorUnauthorized)
+
+	admin, err := usecase.Authenticate(ctx, "admin-key-for-tests-only")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, admin.IsAdmin())
+
+	// tokens are disabled without keys
+	_, err = usecase.AuthenticateToken(ctx, "token")
+	assert.ErrorIs(t, err, domain.ErrorUnauthorized)
+}
+
+func Test_authUsecase_CreateKey(t *testing.T) {
+	usecase, repo := newTestUsecase(t)
+
+	var stored *entity.APIKey
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.APIKey")).Return(nil).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*entity.APIKey)
+	})
+
+	key, err := usecase.CreateKey(context.Background(), &entity.APIKey{Name: "ci", ProjectIds: []int{1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, strings.HasPrefix(key, keyPrefix))
+	assert.Equal(t, HashKey(key), stored.Hash)
+	assert.Equal(t, key[:displayPrefixLength], stored.Prefix)
+	assert.Equal(t, entity.RoleEditor, stored.Role)
+
+	_, err = usecase.CreateKey(context.Background(), &entity.APIKey{Name: "ci"})
+	assert.Error(t, err)
+}
+
+func TestNewAuthUsecase_adminKey(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	for _, adminKey := range []string{"short", "admin-secret"} {
+		_, err := NewAuthUsecase(mocks.NewAPIKeyRepository(t), adminKey, JWTConfig{}, logger)
+		assert.Error(t, err, adminKey)
+	}
+
+	// admin access is disabled
+	usecase, err := NewAuthUsecase(mocks.NewAPIKeyRepository(t), "", JWTConfig{}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = usecase.Authenticate(context.Background(), "")
+	assert.ErrorIs(t, err, domain.ErrorUnauthorized)
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"goods-manager/internal/domain/entity"
+	"math/big"
+	"os"
+	"strconv"
+)
+
+const (
+	defaultRolesClaim = "roles"
+
+	// allProjects is a key of roles claim for role in every project
+	allProjects = "*"
+)
+
+// JWTConfig is configuration of bearer tokens validation.
+// Tokens are disabled if neither HS256 secret nor RS256 keys are set.
+type JWTConfig struct {
+	// HS256Secret is a shared secret of HS256 tokens
+	HS256Secret string
+
+	// RS256PublicKeyFile is a path to PEM encoded public key of RS256 tokens
+	RS256PublicKeyFile string
+
+	// JWKSFile is a path to JSON Web Key Set with RSA keys of RS256 tokens, keys are selected by `kid`
+	JWKSFile string
+
+	// Issuer and Audience are checked if they are not empty
+	Issuer   string
+	Audience string
+
+	// RolesClaim is a name of claim with object mapping project ID (or `*` for all projects) to role.
+	// Default is `roles`.
+	RolesClaim string
+}
+
+// tokenVerifier validates bearer tokens and maps their claims to principal
+type tokenVerifier struct {
+	hs256Secret []byte
+	rsaKey      *rsa.PublicKey
+	jwks        map[string]*rsa.PublicKey
+	parser      *jwt.Parser
+	rolesClaim  string
+}
+
+// newTokenVerifier loads keys from configuration.
+// It returns nil verifier if tokens are disabled.
+func newTokenVerifier(config JWTConfig) (*tokenVerifier, error) {
+	v := &tokenVerifier{hs256Secret: []byte(config.HS256Secret), rolesClaim: config.RolesClaim}
+	if v.rolesClaim == "" {
+		v.rolesClaim = defaultRolesClaim
+	}
+
+	if config.RS256PublicKeyFile != "" {
+		data, err := os.ReadFile(config.RS256PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		v.rsaKey, err = jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed parse RS256 public key: %w", err)
+		}
+	}
+
+	if config.JWKSFile != "" {
+		data, err := os.ReadFile(config.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+
+		v.jwks, err = parseJWKS(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed parse JWKS: %w", err)
+		}
+	}
+
+	var methods []string
+	if len(v.hs256Secret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if v.rsaKey != nil || len(v.jwks) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, nil
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if config.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		opts = append(opts, jwt.WithAudience(config.Audience))
+	}
+	v.parser = jwt.NewParser(opts...)
+
+	return v, nil
+}
+
+// Verify validates token and returns principal with roles from claims
+func (v *tokenVerifier) Verify(token string) (*entity.Principal, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.key); err != nil {
+		return nil, err
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil {
+		return nil, err
+	}
+	if subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+
+	principal := &entity.Principal{Subject: "user:" + subject, Roles: map[int]entity.Role{}}
+
+	rawRoles, ok := claims[v.rolesClaim].(map[string]any)
+	if !ok {
+		return principal, nil
+	}
+
+	for project, rawRole := range rawRoles {
+		roleString, ok := rawRole.(string)
+		if !ok || !entity.Role(roleString).Valid() {
+			return nil, fmt.Errorf("invalid role of project %q", project)
+		}
+		role := entity.Role(roleString)
+
+		if project == allProjects {
+			principal.AllProjectsRole = role
+			continue
+		}
+
+		projectId, err := strconv.Atoi(project)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project %q in roles: %w", project, err)
+		}
+
+		principal.Roles[projectId] = role
+	}
+
+	return principal, nil
+}
+
+// key returns key to verify signature of the token
+func (v *tokenVerifier) key(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.hs256Secret, nil
+	case jwt.SigningMethodRS256.Alg():
+		if kid, ok := token.Header["kid"].(string); ok && len(v.jwks) > 0 {
+			if key, ok := v.jwks[kid]; ok {
+				return key, nil
+			}
+
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+
+		if v.rsaKey != nil {
+			return v.rsaKey, nil
+		}
+
+		// the only key of set can be used without key id
+		if len(v.jwks) == 1 {
+			for _, key := range v.jwks {
+				return key, nil
+			}
+		}
+
+		return nil, errors.New("key id is required")
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// parseJWKS parses RSA keys from JSON Web Key Set, keys of other types are skipped
+func parseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus of key %q: %w", key.Kid, err)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent of key %q: %w", key.Kid, err)
+		}
+
+		keys[key.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("set has no RSA keys")
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"goods-manager/internal/domain/entity"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func signHS256(t *testing.T, secret string, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+func TestTokenVerifier_HS256(t *testing.T) {
+	verifier, err := newTokenVerifier(JWTConfig{HS256Secret: "secret", Issuer: "ui"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := time.Now().Add(time.Hour).Unix()
+
+	principal, err := verifier.Verify(signHS256(t, "secret", jwt.MapClaims{
+		"sub": "42", "iss": "ui", "exp": exp,
+		"roles": map[string]any{"1": "editor", "*": "viewer"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "user:42", principal.Subject)
+	assert.True(t, principal.Can(1, entity.RoleEditor))
+	assert.True(t, principal.Can(2, entity.RoleViewer))
+	assert.False(t, principal.Can(2, entity.RoleEditor))
+	assert.False(t, principal.IsAdmin())
+
+	invalid := map[string]string{
+		"wrong secret": signHS256(t, "other", jwt.MapClaims{"sub": "42", "iss": "ui", "exp": exp}),
+		"expired":      signHS256(t, "secret", jwt.MapClaims{"sub": "42", "iss": "ui", "exp": time.Now().Add(-time.Minute).Unix()}),
+		"without exp":  signHS256(t, "secret", jwt.MapClaims{"sub": "42", "iss": "ui"}),
+		"wrong issuer": signHS256(t, "secret", jwt.MapClaims{"sub": "42", "iss": "other", "exp": exp}),
+		"unknown role": signHS256(t, "secret", jwt.MapClaims{"sub": "42", "iss": "ui", "exp": exp, "roles": map[string]any{"1": "owner"}}),
+		"without sub":  signHS256(t, "secret", jwt.MapClaims{"iss": "ui", "exp": exp}),
+		"not a token":  "token",
+		"alg none":     "eyJhbGciOiJub25lIn0.eyJzdWIiOiI0MiJ9.",
+	}
+
+	for name, token := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := verifier.Verify(token)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTokenVerifier_RS256JWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks, err := json.Marshal(map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": "key-1",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwks, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	verifier, err := newTokenVerifier(JWTConfig{JWKSFile: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(kid string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"sub": "7", "exp": time.Now().Add(time.Hour).Unix(), "roles": map[string]any{"*": "admin"},
+		})
+		token.Header["kid"] = kid
+
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return signed
+	}
+
+	principal, err := verifier.Verify(sign("key-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, principal.IsAdmin())
+
+	_, err = verifier.Verify(sign("key-2"))
+	assert.Error(t, err)
+
+	// HS256 is not allowed when only RSA keys are configured
+	_, err = verifier.Verify(signHS256(t, "secret", jwt.MapClaims{"sub": "7", "exp": time.Now().Add(time.Hour).Unix()}))
+	assert.Error(t, err)
+}
+
+func TestNewTokenVerifier_Disabled(t *testing.T) {
+	verifier, err := newTokenVerifier(JWTConfig{})
+
+	assert.NoError(t, err)
+	assert.Nil(t, verifier)
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/tracing"
+	"goods-manager/internal/transactor"
+)
+
+type checkpointRepository struct {
+	transactor *transactor.Transactor
+}
+
+func (c *checkpointRepository) Get(ctx context.Context, name string) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "checkpointRepository.Get")
+	defer tracing.End(span, &err)
+
+	query := `
+		SELECT last_id FROM backfill_checkpoints
+			WHERE name = $1
+	`
+
+	tx, db := c.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, name)
+	} else {
+		row = db.QueryRowContext(ctx, query, name)
+	}
+
+	var lastId int
+	if err := row.Scan(&lastId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return lastId, nil
+}
+
+func (c *checkpointRepository) Save(ctx context.Context, name string, lastId int) (err error) {
+	ctx, span := tracing.Start(ctx, "checkpointRepository.Save")
+	defer tracing.End(span, &err)
+
+	query := `
+		INSERT INTO backfill_checkpoints (name, last_id)
+			VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET last_id = excluded.last_id, updated_at = NOW()
+	`
+
+	tx, db := c.transactor.Connection(ctx)
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, name, lastId)
+	} else {
+		_, err = db.ExecContext(ctx, query, name, lastId)
+	}
+
+	return err
+}
+
+func NewCheckpointRepository(transactor *transactor.Transactor) domain.CheckpointRepository {
+	return &checkpointRepository{transactor: transactor}
+}
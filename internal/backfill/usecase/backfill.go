@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultBatchSize = 500
+
+	// checkpointName is name of progress of backfill of goods in checkpoints
+	checkpointName = "goods_snapshot"
+
+	// SnapshotActor is actor of snapshot events
+	SnapshotActor = "backfill"
+)
+
+// snapshotNamespace is namespace of UUIDv5 of snapshot events
+var snapshotNamespace = uuid.MustParse("0de0aef0-5f0b-4136-8194-21148b65e82f")
+
+// backfillUsecase implementation `domain.BackfillUsecase`.
+//
+// Goods are read by pages ordered by ID and the last ID of saved page is checkpointed, so interrupted run is resumed.
+// Snapshot event of a good has ID derived from ID of the good and time of its creation, so events of pages written
+// again (e.g. crash before checkpoint or restart) are deduplicated by `ReplacingMergeTree`.
+type backfillUsecase struct {
+	goodRepo       domain.GoodRepository
+	loggerRepo     domain.LoggerRepository
+	checkpointRepo domain.CheckpointRepository
+	batchSize      int
+	logger         *slog.Logger
+}
+
+func (b *backfillUsecase) Run(ctx context.Context, restart bool) (count int, err error) {
+	ctx, span := tracing.Start(ctx, "backfillUsecase.Run")
+	defer tracing.End(span, &err)
+
+	lastId := 0
+	if !restart {
+		lastId, err = b.checkpointRepo.Get(ctx, checkpointName)
+		if err != nil {
+			return 0, fmt.Errorf("failed get checkpoint: %w", err)
+		}
+	}
+
+	b.logger.InfoContext(ctx, "backfill started", slog.Int("after_id", lastId), slog.Int("batch_size", b.batchSize))
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+
+		goods, err := b.goodRepo.ListAfter(ctx, lastId, b.batchSize)
+		if err != nil {
+			return count, fmt.Errorf("failed list goods after %d: %w", lastId, err)
+		}
+
+		if len(goods) == 0 {
+			break
+		}
+
+		events := make([]*entity.Event, 0, len(goods))
+		for _, good := range goods {
+			event, err := snapshotEvent(good)
+			if err != nil {
+				return count, err
+			}
+
+			events = append(events, event)
+		}
+
+		if err := b.loggerRepo.SaveList(ctx, events); err != nil {
+			return count, fmt.Errorf("failed save snapshots of goods after %d: %w", lastId, err)
+		}
+
+		lastId = goods[len(goods)-1].Id
+		count += len(events)
+
+		if err := b.checkpointRepo.Save(ctx, checkpointName, lastId); err != nil {
+			return count, fmt.Errorf("failed save checkpoint: %w", err)
+		}
+
+		b.logger.InfoContext(ctx, "backfill progress", slog.Int("last_id", lastId), slog.Int("count", count))
+	}
+
+	b.logger.InfoContext(ctx, "backfill finished", slog.Int("last_id", lastId), slog.Int("count", count))
+	return count, nil
+}
+
+// snapshotEvent returns event with the current state of the good which occurred at its creation
+func snapshotEvent(good *entity.Good) (*entity.Event, error) {
+	createdAt, err := time.Parse(time.RFC3339Nano, good.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed parse creation time of good %d: %w", good.Id, err)
+	}
+
+	return &entity.Event{
+		Version:    entity.EventVersion,
+		Id:         uuid.NewSHA1(snapshotNamespace, []byte(strconv.Itoa(good.Id)+"/"+good.CreatedAt)).String(),
+		Type:       entity.EventGoodSnapshot,
+		OccurredAt: createdAt.UTC(),
+		Actor:      SnapshotActor,
+		ProjectId:  good.ProjectId,
+		Payload:    good,
+	}, nil
+}
+
+func NewBackfillUsecase(goodRepo domain.GoodRepository, loggerRepo domain.LoggerRepository, checkpointRepo domain.CheckpointRepository, batchSize int, logger *slog.Logger) domain.BackfillUsecase {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	return &backfillUsecase{goodRepo: goodRepo, loggerRepo: loggerRepo, checkpointRepo: checkpointRepo, batchSize: batchSize, logger: logger}
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/mocks"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func Test_backfillUsecase_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		restart    bool
+		checkpoint int
+		// from is ID after which goods are read
+		from int
+	}{
+		{name: "resume from checkpoint", checkpoint: 3, from: 3},
+		{name: "restart", restart: true, from: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goodRepo := mocks.NewGoodRepository(t)
+			goodRepo.On("ListAfter", mock.Anything, tt.from, 2).Return([]*entity.Good{
+				{Id: 4, ProjectId: 1, Name: "first", CreatedAt: "2026-10-01T10:00:00.5Z"},
+				{Id: 7, ProjectId: 2, Name: "second", Removed: true, CreatedAt: "2026-10-02T10:00:00Z"},
+			}, nil)
+			goodRepo.On("ListAfter", mock.Anything, 7, 2).Return([]*entity.Good{
+				{Id: 9, ProjectId: 1, Name: "third", CreatedAt: "2026-10-03T10:00:00Z"},
+			}, nil)
+			goodRepo.On("ListAfter", mock.Anything, 9, 2).Return([]*entity.Good{}, nil)
+
+			var saved []*entity.Event
+			loggerRepo := mocks.NewLoggerRepository(t)
+			loggerRepo.On("SaveList", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { saved = append(saved, args.Get(1).([]*entity.Event)...) }).
+				Return(nil)
+
+			checkpointRepo := mocks.NewCheckpointRepository(t)
+			if !tt.restart {
+				checkpointRepo.On("Get", mock.Anything, checkpointName).Return(tt.checkpoint, nil)
+			}
+			checkpointRepo.On("Save", mock.Anything, checkpointName, 7).Return(nil).Once()
+			checkpointRepo.On("Save", mock.Anything, checkpointName, 9).Return(nil).Once()
+
+			usecase := NewBackfillUsecase(goodRepo, loggerRepo, checkpointRepo, 2, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			count, err := usecase.Run(context.Background(), tt.restart)
+			require.NoError(t, err)
+			assert.Equal(t, 3, count)
+
+			require.Len(t, saved, 3)
+			assert.Equal(t, entity.EventGoodSnapshot, saved[0].Type)
+			assert.Equal(t, time.Date(2026, time.October, 1, 10, 0, 0, 5e8, time.UTC), saved[0].OccurredAt)
+			assert.True(t, saved[1].Payload.Removed)
+
+			// snapshot of the same good has the same ID, so it is deduplicated
+			again, err := snapshotEvent(&entity.Good{Id: 4, CreatedAt: "2026-10-01T10:00:00.5Z"})
+			require.NoError(t, err)
+			assert.Equal(t, saved[0].Id, again.Id)
+			assert.NotEqual(t, saved[0].Id, saved[2].Id)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 var ErrorNotExists = errors.New("item not found")
@@ -16,10 +17,27 @@ type Cache interface {
 	// It returns an error if the operation fails.
 	Get(ctx context.Context, key string, value interface{}) error
 
+	// GetMany retrieves values from the cache based on the provided keys by one request.
+	// Value of `keys[i]` is stored to `values[i]`, `found[i]` reports whether `keys[i]` exists.
+	// It returns an error if the operation fails.
+	GetMany(ctx context.Context, keys []string, values []interface{}) (found []bool, err error)
+
 	// Set sets a value in the cache with the provided key.
 	// It returns an error if the operation fails.
 	Set(ctx context.Context, key string, value interface{}) error
 
+	// SetMany sets values in the cache with the keys of the map by one request.
+	// It returns an error if the operation fails.
+	SetMany(ctx context.Context, values map[string]interface{}) error
+
+	// SetWithTTL sets a value in the cache with the provided key, which expires after ttl.
+	// It returns an error if the operation fails.
+	SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+
+	// SetNX sets a value in the cache with the provided key only if the key does not exist.
+	// It reports whether the value was set and returns an error if the operation fails.
+	SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+
 	// Remove removes a value from the cache based on the provided key.
 	// It returns an error if the operation fails.
 	Remove(ctx context.Context, key string) error
@@ -5,6 +5,8 @@ package cache
 import (
 	context "context"
 
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -69,6 +71,66 @@ func (_c *MockCache_Get_Call) RunAndReturn(run func(context.Context, string, int
 	return _c
 }
 
+// GetMany provides a mock function with given fields: ctx, keys, values
+func (_m *MockCache) GetMany(ctx context.Context, keys []string, values []interface{}) ([]bool, error) {
+	ret := _m.Called(ctx, keys, values)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMany")
+	}
+
+	var r0 []bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []interface{}) ([]bool, error)); ok {
+		return rf(ctx, keys, values)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []interface{}) []bool); ok {
+		r0 = rf(ctx, keys, values)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, []interface{}) error); ok {
+		r1 = rf(ctx, keys, values)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCache_GetMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMany'
+type MockCache_GetMany_Call struct {
+	*mock.Call
+}
+
+// GetMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+//   - values []interface{}
+func (_e *MockCache_Expecter) GetMany(ctx interface{}, keys interface{}, values interface{}) *MockCache_GetMany_Call {
+	return &MockCache_GetMany_Call{Call: _e.mock.On("GetMany", ctx, keys, values)}
+}
+
+func (_c *MockCache_GetMany_Call) Run(run func(ctx context.Context, keys []string, values []interface{})) *MockCache_GetMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].([]interface{}))
+	})
+	return _c
+}
+
+func (_c *MockCache_GetMany_Call) Return(_a0 []bool, _a1 error) *MockCache_GetMany_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCache_GetMany_Call) RunAndReturn(run func(context.Context, []string, []interface{}) ([]bool, error)) *MockCache_GetMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Remove provides a mock function with given fields: ctx, key
 func (_m *MockCache) Remove(ctx context.Context, key string) error {
 	ret := _m.Called(ctx, key)
@@ -164,6 +226,161 @@ func (_c *MockCache_Set_Call) RunAndReturn(run func(context.Context, string, int
 	return _c
 }
 
+// SetMany provides a mock function with given fields: ctx, values
+func (_m *MockCache) SetMany(ctx context.Context, values map[string]interface{}) error {
+	ret := _m.Called(ctx, values)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMany")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}) error); ok {
+		r0 = rf(ctx, values)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCache_SetMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMany'
+type MockCache_SetMany_Call struct {
+	*mock.Call
+}
+
+// SetMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - values map[string]interface{}
+func (_e *MockCache_Expecter) SetMany(ctx interface{}, values interface{}) *MockCache_SetMany_Call {
+	return &MockCache_SetMany_Call{Call: _e.mock.On("SetMany", ctx, values)}
+}
+
+func (_c *MockCache_SetMany_Call) Run(run func(ctx context.Context, values map[string]interface{})) *MockCache_SetMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *MockCache_SetMany_Call) Return(_a0 error) *MockCache_SetMany_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_SetMany_Call) RunAndReturn(run func(context.Context, map[string]interface{}) error) *MockCache_SetMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetNX provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNX")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCache_SetNX_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetNX'
+type MockCache_SetNX_Call struct {
+	*mock.Call
+}
+
+// SetNX is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockCache_Expecter) SetNX(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockCache_SetNX_Call {
+	return &MockCache_SetNX_Call{Call: _e.mock.On("SetNX", ctx, key, value, ttl)}
+}
+
+func (_c *MockCache_SetNX_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockCache_SetNX_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockCache_SetNX_Call) Return(_a0 bool, _a1 error) *MockCache_SetNX_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCache_SetNX_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockCache_SetNX_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetWithTTL provides a mock function with given fields: ctx, key, value, ttl
+func (_m *MockCache) SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWithTTL")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCache_SetWithTTL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWithTTL'
+type MockCache_SetWithTTL_Call struct {
+	*mock.Call
+}
+
+// SetWithTTL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *MockCache_Expecter) SetWithTTL(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *MockCache_SetWithTTL_Call {
+	return &MockCache_SetWithTTL_Call{Call: _e.mock.On("SetWithTTL", ctx, key, value, ttl)}
+}
+
+func (_c *MockCache_SetWithTTL_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *MockCache_SetWithTTL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockCache_SetWithTTL_Call) Return(_a0 error) *MockCache_SetWithTTL_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_SetWithTTL_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) error) *MockCache_SetWithTTL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockCache creates a new instance of MockCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockCache(t interface {
@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -31,6 +33,36 @@ func (_m *Cache) Get(ctx context.Context, key string, value interface{}) error {
 	return r0
 }
 
+// GetMany provides a mock function with given fields: ctx, keys, values
+func (_m *Cache) GetMany(ctx context.Context, keys []string, values []interface{}) ([]bool, error) {
+	ret := _m.Called(ctx, keys, values)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMany")
+	}
+
+	var r0 []bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []interface{}) ([]bool, error)); ok {
+		return rf(ctx, keys, values)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []interface{}) []bool); ok {
+		r0 = rf(ctx, keys, values)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, []interface{}) error); ok {
+		r1 = rf(ctx, keys, values)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Remove provides a mock function with given fields: ctx, key
 func (_m *Cache) Remove(ctx context.Context, key string) error {
 	ret := _m.Called(ctx, key)
@@ -67,6 +99,70 @@ func (_m *Cache) Set(ctx context.Context, key string, value interface{}) error {
 	return r0
 }
 
+// SetMany provides a mock function with given fields: ctx, values
+func (_m *Cache) SetMany(ctx context.Context, values map[string]interface{}) error {
+	ret := _m.Called(ctx, values)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMany")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}) error); ok {
+		r0 = rf(ctx, values)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetNX provides a mock function with given fields: ctx, key, value, ttl
+func (_m *Cache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNX")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetWithTTL provides a mock function with given fields: ctx, key, value, ttl
+func (_m *Cache) SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWithTTL")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewCache creates a new instance of Cache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCache(t interface {
@@ -4,8 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"goods-manager/internal/cache"
+	"goods-manager/internal/tracing"
+	"strings"
 	"time"
 )
 
@@ -14,23 +19,66 @@ type Cache struct {
 	client *redis.Client
 }
 
-func (c Cache) Get(ctx context.Context, key string, value any) error {
+func (c Cache) Get(ctx context.Context, key string, value any) (err error) {
+	ctx, span := startSpan(ctx, "Cache.Get", key)
+	defer tracing.End(span, &err)
+
 	res, err := c.client.Get(ctx, key).Bytes()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
+			span.SetAttributes(attribute.Bool("cache.hit", false))
 			return cache.ErrorNotExists
 		}
 
 		return err
 	}
 
+	span.SetAttributes(attribute.Bool("cache.hit", true))
 	return json.Unmarshal(res, value)
 }
 
+func (c Cache) GetMany(ctx context.Context, keys []string, values []any) (_ []bool, err error) {
+	ctx, span := startSpan(ctx, "Cache.GetMany", strings.Join(keys, ","))
+	defer tracing.End(span, &err)
+
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("count of keys %d is not equal to count of values %d", len(keys), len(values))
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	res, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]bool, len(keys))
+	hits := 0
+	for i, item := range res {
+		data, ok := item.(string)
+		if !ok {
+			continue
+		}
+
+		if err := json.Unmarshal([]byte(data), values[i]); err != nil {
+			return nil, err
+		}
+		found[i] = true
+		hits++
+	}
+
+	span.SetAttributes(attribute.Int("cache.hits", hits), attribute.Int("cache.misses", len(keys)-hits))
+	return found, nil
+}
+
 // Set data to store.
 //
 // Default ttl is 1 minute
-func (c Cache) Set(ctx context.Context, key string, value any) error {
+func (c Cache) Set(ctx context.Context, key string, value any) (err error) {
+	ctx, span := startSpan(ctx, "Cache.Set", key)
+	defer tracing.End(span, &err)
+
 	valueJson, err := json.Marshal(value)
 	if err != nil {
 		return err
@@ -39,10 +87,75 @@ func (c Cache) Set(ctx context.Context, key string, value any) error {
 	return c.client.Set(ctx, key, valueJson, 1*time.Minute).Err()
 }
 
-func (c Cache) Remove(ctx context.Context, key string) error {
+// SetMany sets data to store by pipeline.
+//
+// Default ttl is 1 minute
+func (c Cache) SetMany(ctx context.Context, values map[string]any) (err error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	ctx, span := startSpan(ctx, "Cache.SetMany", strings.Join(keys, ","))
+	defer tracing.End(span, &err)
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	pipe := c.client.Pipeline()
+	for key, value := range values {
+		valueJson, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		pipe.Set(ctx, key, valueJson, 1*time.Minute)
+	}
+
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (c Cache) SetWithTTL(ctx context.Context, key string, value any, ttl time.Duration) (err error) {
+	ctx, span := startSpan(ctx, "Cache.SetWithTTL", key)
+	defer tracing.End(span, &err)
+
+	valueJson, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Set(ctx, key, valueJson, ttl).Err()
+}
+
+func (c Cache) SetNX(ctx context.Context, key string, value any, ttl time.Duration) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "Cache.SetNX", key)
+	defer tracing.End(span, &err)
+
+	valueJson, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	return c.client.SetNX(ctx, key, valueJson, ttl).Result()
+}
+
+func (c Cache) Remove(ctx context.Context, key string) (err error) {
+	ctx, span := startSpan(ctx, "Cache.Remove", key)
+	defer tracing.End(span, &err)
+
 	return c.client.Del(ctx, key).Err()
 }
 
+// startSpan starts client span for operation with `key`
+func startSpan(ctx context.Context, name, key string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", "redis"), attribute.String("cache.key", key)),
+	)
+}
+
 func NewCache(client *redis.Client) cache.Cache {
 	return &Cache{client: client}
 }
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/httperror"
+	"goods-manager/internal/validation"
+	"net/http"
+)
+
+// DeadLetterController serves dead letters to admins by routes `/v2/admin/dead-letters[/:id]`
+type DeadLetterController struct {
+	deadLetterUsecase domain.DeadLetterUsecase
+}
+
+// List this function is used for get dead letters.
+//
+// @Summary		Get dead letters
+// @Description	Messages which can't be decoded or saved after all attempts, the latest first.
+// @Tags		dead letters
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		replayed	query		bool		false	"Include replayed messages"
+// @Param		offset		query		int			false	"Offset of select"	minimum(0)	default(0)
+// @Param		limit		query		int			false	"Limit of rows"		minimum(1)	maximum(100)	default(20)
+//
+// @Success		200		{object}	ListDeadLettersResponse	"Dead letters"
+// @Failure		400		{object}	httperror.Response		"Invalid input"
+// @Failure		401		{object}	httperror.Response		"Unauthorized"
+// @Failure		403		{object}	httperror.Response		"Forbidden"
+// @Failure		429		{object}	httperror.Response		"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response		"Server error"
+// @Router		/v2/admin/dead-letters		[get]
+func (d *DeadLetterController) List(c *gin.Context) {
+	var query ListQuery
+	if !validation.Bind(c, &query, c.ShouldBindQuery) {
+		return
+	}
+
+	deadLetters, err := d.deadLetterUsecase.List(c, query.Replayed, query.Limit, query.Offset)
+	if err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	resp := ListDeadLettersResponse{DeadLetters: make([]DeadLetterResponse, 0, len(deadLetters)), Limit: query.Limit, Offset: query.Offset}
+	for _, deadLetter := range deadLetters {
+		resp.DeadLetters = append(resp.DeadLetters, DeadLetterResponseFromEntity(deadLetter))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Get this function is used for get dead letter.
+//
+// @Summary		Get dead letter
+// @Tags		dead letters
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		id		path		int			true	"ID of dead letter"	minimum(1)
+//
+// @Success		200		{object}	DeadLetterResponse	"Dead letter with body of the message"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Forbidden"
+// @Failure		404		{object}	httperror.Response	"Dead letter not found"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/admin/dead-letters/{id}		[get]
+func (d *DeadLetterController) Get(c *gin.Context) {
+	var path DeadLetterPath
+	if !validation.Bind(c, &path, c.ShouldBindUri) {
+		return
+	}
+
+	deadLetter, err := d.deadLetterUsecase.Get(c, path.Id)
+	if err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, DeadLetterResponseFromEntity(deadLetter))
+}
+
+// Replay this function is used for publish dead letter again.
+//
+// @Summary		Replay dead letter
+// @Description	Message is published again with the same subject, headers and body, it is replayed only once.
+// @Tags		dead letters
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		id				path		int			true	"ID of dead letter"	minimum(1)
+// @Param		Idempotency-Key	header		string		false	"Key to retry request safely"
+//
+// @Success		200		{object}	DeadLetterResponse	"Replayed dead letter"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Forbidden"
+// @Failure		404		{object}	httperror.Response	"Dead letter not found"
+// @Failure		409		{object}	httperror.Response	"Dead letter is already replayed"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/admin/dead-letters/{id}/replay		[post]
+func (d *DeadLetterController) Replay(c *gin.Context) {
+	var path DeadLetterPath
+	if !validation.Bind(c, &path, c.ShouldBindUri) {
+		return
+	}
+
+	deadLetter, err := d.deadLetterUsecase.Replay(c, path.Id)
+	if err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, DeadLetterResponseFromEntity(deadLetter))
+}
+
+func NewDeadLetterController(deadLetterUsecase domain.DeadLetterUsecase) *DeadLetterController {
+	return &DeadLetterController{deadLetterUsecase: deadLetterUsecase}
+}
@@ -0,0 +1,12 @@
+package controller
+
+type DeadLetterPath struct {
+	Id int64 `uri:"id" binding:"required,min=1"`
+}
+
+type ListQuery struct {
+	// Replayed includes replayed messages
+	Replayed bool `form:"replayed"`
+	Limit    int  `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset   int  `form:"offset,default=0" binding:"min=0"`
+}
@@ -0,0 +1,20 @@
+package controller
+
+import "goods-manager/internal/domain/entity"
+
+type DeadLetterResponse struct {
+	*entity.DeadLetter
+
+	// Data is body of the message, it may be malformed
+	Data string `json:"data"`
+}
+
+type ListDeadLettersResponse struct {
+	DeadLetters []DeadLetterResponse `json:"dead_letters"`
+	Limit       int                  `json:"limit"`
+	Offset      int                  `json:"offset"`
+}
+
+func DeadLetterResponseFromEntity(deadLetter *entity.DeadLetter) DeadLetterResponse {
+	return DeadLetterResponse{DeadLetter: deadLetter, Data: string(deadLetter.Data)}
+}
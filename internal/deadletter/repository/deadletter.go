@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
+	"goods-manager/internal/transactor"
+)
+
+type deadLetterRepository struct {
+	transactor *transactor.Transactor
+}
+
+// Create inserts message and sets its ID and creation timestamp
+func (d *deadLetterRepository) Create(ctx context.Context, deadLetter *entity.DeadLetter) (err error) {
+	ctx, span := tracing.Start(ctx, "deadLetterRepository.Create")
+	defer tracing.End(span, &err)
+
+	header, err := json.Marshal(deadLetter.Header)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO dead_letters (subject, header, data, reason, attempts)
+			VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	tx, db := d.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, deadLetter.Subject, header, deadLetter.Data, deadLetter.Reason, deadLetter.Attempts)
+	} else {
+		row = db.QueryRowContext(ctx, query, deadLetter.Subject, header, deadLetter.Data, deadLetter.Reason, deadLetter.Attempts)
+	}
+
+	return row.Scan(&deadLetter.Id, &deadLetter.CreatedAt)
+}
+
+// Get gets message by ID
+func (d *deadLetterRepository) Get(ctx context.Context, id int64) (_ *entity.DeadLetter, err error) {
+	ctx, span := tracing.Start(ctx, "deadLetterRepository.Get")
+	defer tracing.End(span, &err)
+
+	query := `
+		SELECT id, subject, header, data, reason, attempts, created_at, replayed_at FROM dead_letters
+			WHERE id = $1
+	`
+
+	tx, db := d.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, id)
+	} else {
+		row = db.QueryRowContext(ctx, query, id)
+	}
+
+	deadLetter, err := scanDeadLetter(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrorDeadLetterNotFound
+		}
+
+		return nil, err
+	}
+
+	return deadLetter, nil
+}
+
+// List gets page of messages, the latest first
+func (d *deadLetterRepository) List(ctx context.Context, replayed bool, limit, offset int) (_ []*entity.DeadLetter, err error) {
+	ctx, span := tracing.Start(ctx, "deadLetterRepository.List")
+	defer tracing.End(span, &err)
+
+	query := `
+		SELECT id, subject, header, data, reason, attempts, created_at, replayed_at FROM dead_letters
+			WHERE $1 OR replayed_at IS NULL
+			ORDER BY id DESC
+			LIMIT $2 OFFSET $3
+	`
+
+	tx, db := d.transactor.Connection(ctx)
+	var rows *sql.Rows
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, replayed, limit, offset)
+	} else {
+		rows, err = db.QueryContext(ctx, query, replayed, limit, offset)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeRows(rows, &err)
+
+	deadLetters := make([]*entity.DeadLetter, 0)
+	for rows.Next() {
+		deadLetter, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deadLetters, nil
+}
+
+// MarkReplayed sets replay timestamp of the message if it is not replayed
+func (d *deadLetterRepository) MarkReplayed(ctx context.Context, deadLetter *entity.DeadLetter) (_ bool, err error) {
+	ctx, span := tracing.Start(ctx, "deadLetterRepository.MarkReplayed")
+	defer tracing.End(span, &err)
+
+	query := `
+		UPDATE dead_letters SET replayed_at = NOW()
+			WHERE id = $1 AND replayed_at IS NULL
+		RETURNING replayed_at
+	`
+
+	tx, db := d.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, deadLetter.Id)
+	} else {
+		row = db.QueryRowContext(ctx, query, deadLetter.Id)
+	}
+
+	var replayedAt string
+	if err := row.Scan(&replayedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	deadLetter.ReplayedAt = &replayedAt
+	return true, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDeadLetter(row scanner) (*entity.DeadLetter, error) {
+	var deadLetter entity.DeadLetter
+	var header []byte
+	err := row.Scan(&deadLetter.Id, &deadLetter.Subject, &header, &deadLetter.Data, &deadLetter.Reason,
+		&deadLetter.Attempts, &deadLetter.CreatedAt, &deadLetter.ReplayedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(header, &deadLetter.Header); err != nil {
+		return nil, fmt.Errorf("failed unmarshal header of dead letter %d: %w", deadLetter.Id, err)
+	}
+
+	return &deadLetter, nil
+}
+
+func closeRows(rows *sql.Rows, err *error) {
+	if closeErr := rows.Close(); closeErr != nil {
+		*err = errors.Join(*err, fmt.Errorf("failed close rows: %w", closeErr))
+	}
+}
+
+func NewDeadLetterRepository(transactor *transactor.Transactor) domain.DeadLetterRepository {
+	return &deadLetterRepository{transactor: transactor}
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"github.com/nats-io/nats.go"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	usecase2 "goods-manager/internal/logger/usecase"
+	"goods-manager/internal/tracing"
+	"goods-manager/internal/transactor"
+	"log/slog"
+	"maps"
+)
+
+// deadLetterUsecase implementation `domain.DeadLetterUsecase`.
+//
+// Replayed message is enqueued to outbox in the transaction of marking, so it is published once it is marked.
+type deadLetterUsecase struct {
+	deadLetterRepo domain.DeadLetterRepository
+	outboxUsecase  domain.OutboxUsecase
+	transactor     *transactor.Transactor
+	logger         *slog.Logger
+}
+
+func (d *deadLetterUsecase) Add(ctx context.Context, deadLetter *entity.DeadLetter) (err error) {
+	ctx, span := tracing.Start(ctx, "deadLetterUsecase.Add")
+	defer tracing.End(span, &err)
+
+	if err := d.deadLetterRepo.Create(ctx, deadLetter); err != nil {
+		return err
+	}
+
+	d.logger.WarnContext(ctx, "message is dead", slog.Int64("id", deadLetter.Id), slog.String("subject", deadLetter.Subject),
+		slog.String("reason", deadLetter.Reason), slog.Int("attempts", deadLetter.Attempts))
+	return nil
+}
+
+func (d *deadLetterUsecase) Get(ctx context.Context, id int64) (_ *entity.DeadLetter, err error) {
+	ctx, span := tracing.Start(ctx, "deadLetterUsecase.Get")
+	defer tracing.End(span, &err)
+
+	return d.deadLetterRepo.Get(ctx, id)
+}
+
+func (d *deadLetterUsecase) List(ctx context.Context, replayed bool, limit, offset int) (_ []*entity.DeadLetter, err error) {
+	ctx, span := tracing.Start(ctx, "deadLetterUsecase.List")
+	defer tracing.End(span, &err)
+
+	return d.deadLetterRepo.List(ctx, replayed, limit, offset)
+}
+
+// Replay marks message replayed and enqueues it to outbox with the same headers and data.
+// Events of goods are enqueued to `usecase2.ReplaySubject`, so only logger receives them again.
+func (d *deadLetterUsecase) Replay(ctx context.Context, id int64) (_ *entity.DeadLetter, err error) {
+	ctx, span := tracing.Start(ctx, "deadLetterUsecase.Replay")
+	defer tracing.End(span, &err)
+
+	var deadLetter *entity.DeadLetter
+	err = d.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		deadLetter, err = d.deadLetterRepo.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		marked, err := d.deadLetterRepo.MarkReplayed(ctx, deadLetter)
+		if err != nil {
+			return err
+		}
+
+		if !marked {
+			return domain.ErrorDeadLetterReplayed
+		}
+
+		// JetStream drops message with ID of already stored one, so replay is published without it.
+		// ClickHouse deduplicates events by `Event-Id`, if the event was saved before it died.
+		header := maps.Clone(deadLetter.Header)
+		delete(header, nats.MsgIdHdr)
+
+		return d.outboxUsecase.Enqueue(ctx, &entity.OutboxMessage{Subject: replaySubject(deadLetter.Subject), Header: header, Data: deadLetter.Data})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.logger.InfoContext(ctx, "dead letter replayed", slog.Int64("id", id), slog.String("subject", deadLetter.Subject))
+	return deadLetter, nil
+}
+
+// replaySubject returns subject of replayed message, subjects of other consumers are kept
+func replaySubject(subject string) string {
+	if subject == usecase2.Subject {
+		return usecase2.ReplaySubject
+	}
+
+	return subject
+}
+
+func NewDeadLetterUsecase(deadLetterRepo domain.DeadLetterRepository, outboxUsecase domain.OutboxUsecase, transactor *transactor.Transactor, logger *slog.Logger) domain.DeadLetterUsecase {
+	return &deadLetterUsecase{deadLetterRepo: deadLetterRepo, outboxUsecase: outboxUsecase, transactor: transactor, logger: logger}
+}
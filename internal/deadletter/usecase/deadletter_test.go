@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/transactor"
+	"goods-manager/mocks"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func Test_deadLetterUsecase_Replay(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		header  map[string][]string
+		marked  bool
+		wantErr error
+	}{
+		{name: "replayed", header: map[string][]string{"Event-Id": {"1"}}, marked: true},
+		{name: "already replayed", header: map[string][]string{"Event-Id": {"1"}}, marked: false, wantErr: domain.ErrorDeadLetterReplayed},
+		{
+			// replay died again, it is still published to logger only
+			name:    "replayed replay",
+			subject: "logger:good:replay",
+			header:  map[string][]string{"Event-Id": {"1"}},
+			marked:  true,
+		},
+		{
+			// stream would drop replay as duplicate of the original message
+			name:   "replayed in JetStream mode",
+			header: map[string][]string{"Event-Id": {"1"}, nats.MsgIdHdr: {"1"}},
+			marked: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, sql, err := sqlmock.New()
+			require.NoError(t, err)
+			sql.ExpectBegin()
+			if tt.wantErr != nil {
+				sql.ExpectRollback()
+			} else {
+				sql.ExpectCommit()
+			}
+
+			subject := tt.subject
+			if subject == "" {
+				subject = "logger:good"
+			}
+
+			deadLetter := &entity.DeadLetter{Id: 1, Subject: subject, Header: tt.header, Data: []byte(`{}`)}
+			deadLetterRepo := mocks.NewDeadLetterRepository(t)
+			deadLetterRepo.On("Get", mock.Anything, int64(1)).Return(deadLetter, nil)
+			deadLetterRepo.On("MarkReplayed", mock.Anything, deadLetter).Return(tt.marked, nil)
+
+			outboxUsecase := mocks.NewOutboxUsecase(t)
+			if tt.wantErr == nil {
+				header := map[string][]string{"Event-Id": {"1"}}
+				outboxUsecase.On("Enqueue", mock.Anything, &entity.OutboxMessage{Subject: "logger:good:replay", Header: header, Data: deadLetter.Data}).Return(nil)
+			}
+
+			usecase := NewDeadLetterUsecase(deadLetterRepo, outboxUsecase, transactor.NewTransactor(db), slog.New(slog.NewTextHandler(io.Discard, nil)))
+			got, err := usecase.Replay(context.Background(), 1)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, deadLetter, got)
+			}
+			assert.NoError(t, sql.ExpectationsWereMet())
+		})
+	}
+}
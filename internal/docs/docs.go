@@ -15,8 +15,210 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/auth/key/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Create API key scoped to projects",
+                "parameters": [
+                    {
+                        "description": "Name and projects of the key",
+                        "name": "key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreateKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created key, plain key is returned only once",
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreateKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/key/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get list of active API keys",
+                "responses": {
+                    "200": {
+                        "description": "API keys without plain keys",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ListKeysResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/key/remove": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Revoke API key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID of API key",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Key was revoked",
+                        "schema": {
+                            "$ref": "#/definitions/controller.RevokeKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Key not found",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/good/create": {
             "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -27,8 +229,10 @@ const docTemplate = `{
                     "good"
                 ],
                 "summary": "Add a new good to the store",
+                "deprecated": true,
                 "parameters": [
                     {
+                        "minimum": 1,
                         "type": "integer",
                         "description": "Project ID",
                         "name": "projectId",
@@ -41,8 +245,14 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Good"
+                            "$ref": "#/definitions/controller.GoodRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -55,13 +265,43 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid input",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found or removed",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key is reused or in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
                         }
                     },
                     "500": {
                         "description": "Server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httperror.Response"
                         }
                     }
                 }
@@ -69,6 +309,14 @@ const docTemplate = `{
         },
         "/good/list": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -79,20 +327,32 @@ const docTemplate = `{
                     "good"
                 ],
                 "summary": "Get list goods",
+                "deprecated": true,
                 "parameters": [
                     {
+                        "minimum": 1,
                         "type": "integer",
-                        "description": "Offset of select",
-                        "name": "offset",
+                        "description": "Project ID",
+                        "name": "projectId",
                         "in": "query",
                         "required": true
                     },
                     {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Offset of select",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
                         "type": "integer",
+                        "default": 10,
                         "description": "Limit of rows",
                         "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -105,13 +365,31 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid input",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
                         }
                     },
                     "500": {
                         "description": "Server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httperror.Response"
                         }
                     }
                 }
@@ -119,6 +397,14 @@ const docTemplate = `{
         },
         "/good/remove": {
             "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
@@ -126,8 +412,10 @@ const docTemplate = `{
                     "good"
                 ],
                 "summary": "Delete good",
+                "deprecated": true,
                 "parameters": [
                     {
+                        "minimum": 1,
                         "type": "integer",
                         "description": "Project ID",
                         "name": "projectId",
@@ -135,11 +423,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
+                        "minimum": 1,
                         "type": "integer",
                         "description": "ID of good",
                         "name": "id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -152,19 +447,43 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid input",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
                         }
                     },
                     "404": {
                         "description": "Good not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key is reused or in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
                         }
                     },
                     "500": {
                         "description": "Server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httperror.Response"
                         }
                     }
                 }
@@ -172,6 +491,14 @@ const docTemplate = `{
         },
         "/good/reprioritiize": {
             "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
@@ -179,8 +506,10 @@ const docTemplate = `{
                     "good"
                 ],
                 "summary": "Reprioritize good priority",
+                "deprecated": true,
                 "parameters": [
                     {
+                        "minimum": 1,
                         "type": "integer",
                         "description": "Project ID",
                         "name": "projectId",
@@ -188,6 +517,7 @@ const docTemplate = `{
                         "required": true
                     },
                     {
+                        "minimum": 1,
                         "type": "integer",
                         "description": "ID of good",
                         "name": "id",
@@ -202,6 +532,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/controller.PrioritizeRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -214,19 +550,43 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid input",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
                         }
                     },
                     "404": {
                         "description": "Good not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key is reused or in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
                         }
                     },
                     "500": {
                         "description": "Server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httperror.Response"
                         }
                     }
                 }
@@ -234,8 +594,18 @@ const docTemplate = `{
         },
         "/good/update": {
             "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Body is JSON merge patch (RFC 7396): omitted fields are not changed, ` + "`" + `null` + "`" + ` clears description.",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "application/merge-patch+json"
                 ],
                 "produces": [
                     "application/json"
@@ -244,8 +614,10 @@ const docTemplate = `{
                     "good"
                 ],
                 "summary": "Update good",
+                "deprecated": true,
                 "parameters": [
                     {
+                        "minimum": 1,
                         "type": "integer",
                         "description": "Project ID",
                         "name": "projectId",
@@ -253,6 +625,7 @@ const docTemplate = `{
                         "required": true
                     },
                     {
+                        "minimum": 1,
                         "type": "integer",
                         "description": "ID of good",
                         "name": "id",
@@ -260,13 +633,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Good object that needs update",
+                        "description": "Changed fields of the good",
                         "name": "good",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Good"
+                            "$ref": "#/definitions/controller.PatchGoodRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -279,26 +658,2275 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid input",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
                         }
                     },
                     "404": {
                         "description": "Good not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key is reused or in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
                         }
                     },
                     "500": {
                         "description": "Server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httperror.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
+        },
+        "/v2/admin/dead-letters": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Messages which can't be decoded or saved after all attempts, the latest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dead letters"
+                ],
+                "summary": "Get dead letters",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include replayed messages",
+                        "name": "replayed",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset of select",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit of rows",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dead letters",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ListDeadLettersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/admin/dead-letters/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dead letters"
+                ],
+                "summary": "Get dead letter",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "ID of dead letter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dead letter with body of the message",
+                        "schema": {
+                            "$ref": "#/definitions/controller.DeadLetterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Dead letter not found",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/admin/dead-letters/{id}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Message is published again with the same subject, headers and body, it is replayed only once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dead letters"
+                ],
+                "summary": "Replay dead letter",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "ID of dead letter",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Replayed dead letter",
+                        "schema": {
+                            "$ref": "#/definitions/controller.DeadLetterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Dead letter not found",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Dead letter is already replayed",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/projects/{projectId}/analytics/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Counts of events of goods of the project by types and time buckets, buckets without events are omitted.\nDefault time range is the last 30 days, it is rounded to hours.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get counts of events by time buckets",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "good.created",
+                                "good.updated",
+                                "good.deleted",
+                                "good.reprioritized",
+                                "good.priority_changed",
+                                "good.snapshot"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Types of events, all types by default",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hour",
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Size of time bucket",
+                        "name": "bucket",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Inclusive start of time range, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Exclusive end of time range, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Counts of events in order of buckets",
+                        "schema": {
+                            "$ref": "#/definitions/controller.TimeSeriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/projects/{projectId}/analytics/top-goods": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Goods of the project with the most events of the types, e.g. ` + "`" + `good.reprioritized` + "`" + ` for the most reordered goods.\nDefault time range is the last 30 days, it is rounded to days.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get top goods by count of events",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "good.created",
+                                "good.updated",
+                                "good.deleted",
+                                "good.reprioritized",
+                                "good.priority_changed",
+                                "good.snapshot"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Types of events, all types by default",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Inclusive start of time range, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Exclusive end of time range, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Count of goods",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Goods with the most events first",
+                        "schema": {
+                            "$ref": "#/definitions/controller.TopGoodsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/projects/{projectId}/goods": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods v2"
+                ],
+                "summary": "Get list goods of the project",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset of select",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit of rows",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Goods objects and metadata",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods v2"
+                ],
+                "summary": "Add a new good to the project",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Good that needs to be added",
+                        "name": "good",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.GoodRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Good that was added",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Good"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the good"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found or removed",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key is reused or in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/projects/{projectId}/goods/batch": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods v2"
+                ],
+                "summary": "Get goods by IDs",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "IDs of goods, max 100",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Found goods in order of IDs and IDs which are not found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.BatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/projects/{projectId}/goods/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events: ` + "`" + `good` + "`" + ` event with envelope of the event as data for every change, ` + "`" + `reset` + "`" + ` event if changes after ` + "`" + `Last-Event-ID` + "`" + ` are lost and goods must be reloaded.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "goods v2"
+                ],
+                "summary": "Stream changes of goods of the project",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last received event to resume after",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last received event, used if header is not set",
+                        "name": "lastEventId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of events",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/projects/{projectId}/goods/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Events of changes of goods of the project with their states before and after them, the latest first.\nHistory is stored asynchronously, so the latest changes may be missing.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "history"
+                ],
+                "summary": "Get history of goods of the project",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Inclusive start of time range, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Exclusive end of time range, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset of select",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit of rows",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Events of goods of the project",
+                        "schema": {
+                            "$ref": "#/definitions/controller.HistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/projects/{projectId}/goods/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods v2"
+                ],
+                "summary": "Get good",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "ID of good",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Good object",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Good"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Good not found",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods v2"
+                ],
+                "summary": "Replace good",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "ID of good",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New state of the good",
+                        "name": "good",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.GoodRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Good that was updated",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Good"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Good not found",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key is reused or in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods v2"
+                ],
+                "summary": "Delete good",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "ID of good",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Good that was deleted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Good"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Good not found",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key is reused or in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Body is JSON merge patch (RFC 7396): omitted fields are not changed, ` + "`" + `null` + "`" + ` clears description.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods v2"
+                ],
+                "summary": "Update good partially",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "ID of good",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changed fields of the good",
+                        "name": "good",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.PatchGoodRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Good that was updated",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Good"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Good not found",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key is reused or in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/projects/{projectId}/goods/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Events of changes of the good with its state before and after them, the latest first.\nHistory is stored asynchronously, so the latest changes may be missing.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "history"
+                ],
+                "summary": "Get history of the good",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Good ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Inclusive start of time range, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Exclusive end of time range, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset of select",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit of rows",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Events of the good",
+                        "schema": {
+                            "$ref": "#/definitions/controller.HistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/projects/{projectId}/goods/{id}/move": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods v2"
+                ],
+                "summary": "Move good to new priority",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "ID of good",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New priority",
+                        "name": "good",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.PrioritizeRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key to retry request safely",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List goods where was update priority",
+                        "schema": {
+                            "$ref": "#/definitions/controller.PrioritizeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Good not found",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key is reused or in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/projects/{projectId}/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhooks of the project",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhooks without secrets",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ListWebhooksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Requests are signed by returned secret: ` + "`" + `X-Webhook-Signature` + "`" + ` is ` + "`" + `sha256=` + "`" + ` and hex of HMAC-SHA256 of ` + "`" + `\u003cX-Webhook-Timestamp\u003e.\u003cbody\u003e` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create webhook",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "URL and event types",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Webhook with secret",
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreateWebhookResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the webhook"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/projects/{projectId}/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "ID of webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook without secret",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "ID of webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Disabled webhook is enabled by ` + "`" + `{\"enabled\": true}` + "`" + `, its failures are reset.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update webhook partially",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "ID of webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changed fields of the webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.PatchWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook that was updated",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/projects/{projectId}/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get deliveries of webhook",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "ID of webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset of select",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit of rows",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deliveries, the latest first",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ListDeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Project is out of scope or role is not enough",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/httperror.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controller.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "goods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Good"
+                    }
+                },
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "controller.CreateKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "project_ids"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "project_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "role": {
+                    "description": "Role of the key in its projects, default is editor",
+                    "enum": [
+                        "viewer",
+                        "editor",
+                        "admin"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Role"
+                        }
+                    ]
+                }
+            }
+        },
+        "controller.CreateKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "Key is plain API key, it is returned only once",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "project_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "revoked": {
+                    "type": "boolean"
+                },
+                "role": {
+                    "$ref": "#/definitions/entity.Role"
+                }
+            }
+        },
+        "controller.CreateWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Enabled is false if webhook is disabled by user or because of failed deliveries",
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "description": "EventTypes filters delivered events, all events are delivered if it is empty",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.EventType"
+                    }
+                },
+                "failures": {
+                    "description": "Failures is count of consecutive failed attempts of delivery",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "Secret signs webhook requests, it is returned only once",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.DeadLetterResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Attempts is count of attempts of processing before message is dead",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "Data is body of the message, it may be malformed",
+                    "type": "string"
+                },
+                "header": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "Reason is error of the last attempt of processing",
+                    "type": "string"
+                },
+                "replayed_at": {
+                    "description": "ReplayedAt is time of replay, nil if message is not replayed",
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.GoodRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "controller.HistoryResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Event"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.ListDeadLettersResponse": {
+            "type": "object",
+            "properties": {
+                "dead_letters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.DeadLetterResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.ListDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.WebhookDelivery"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.ListKeysResponse": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.APIKey"
+                    }
+                }
+            }
+        },
         "controller.ListResponse": {
             "type": "object",
             "properties": {
@@ -313,6 +2941,17 @@ const docTemplate = `{
                 }
             }
         },
+        "controller.ListWebhooksResponse": {
+            "type": "object",
+            "properties": {
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Webhook"
+                    }
+                }
+            }
+        },
         "controller.Meta": {
             "type": "object",
             "properties": {
@@ -330,11 +2969,57 @@ const docTemplate = `{
                 }
             }
         },
+        "controller.PatchGoodRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Description is cleared by ` + "`" + `null` + "`" + `",
+                    "type": "string",
+                    "maxLength": 255,
+                    "x-nullable": true
+                },
+                "name": {
+                    "description": "Name can't be removed by ` + "`" + `null` + "`" + `",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "controller.PatchWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "type": "array",
+                    "maxItems": 4,
+                    "items": {
+                        "enum": [
+                            "good.created",
+                            "good.updated",
+                            "good.deleted",
+                            "good.reprioritized"
+                        ],
+                        "$ref": "#/definitions/entity.EventType"
+                    }
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
         "controller.PrioritizeRequest": {
             "type": "object",
+            "required": [
+                "newPriority"
+            ],
             "properties": {
                 "newPriority": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 1
                 }
             }
         },
@@ -349,6 +3034,42 @@ const docTemplate = `{
                 }
             }
         },
+        "controller.RevokeKeyResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "revoked": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "controller.TimeSeriesResponse": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "$ref": "#/definitions/entity.Bucket"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.SeriesPoint"
+                    }
+                }
+            }
+        },
+        "controller.TopGoodsResponse": {
+            "type": "object",
+            "properties": {
+                "goods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.GoodCount"
+                    }
+                }
+            }
+        },
         "controller.UpratedPriority": {
             "type": "object",
             "properties": {
@@ -360,6 +3081,163 @@ const docTemplate = `{
                 }
             }
         },
+        "controller.WebhookRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "event_types": {
+                    "description": "EventTypes filters delivered events, all events are delivered if it is empty",
+                    "type": "array",
+                    "maxItems": 4,
+                    "items": {
+                        "enum": [
+                            "good.created",
+                            "good.updated",
+                            "good.deleted",
+                            "good.reprioritized"
+                        ],
+                        "$ref": "#/definitions/entity.EventType"
+                    }
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/hooks/goods"
+                }
+            }
+        },
+        "domain.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "project_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "revoked": {
+                    "type": "boolean"
+                },
+                "role": {
+                    "$ref": "#/definitions/entity.Role"
+                }
+            }
+        },
+        "entity.Bucket": {
+            "type": "string",
+            "enum": [
+                "hour",
+                "day",
+                "week",
+                "month"
+            ],
+            "x-enum-varnames": [
+                "BucketHour",
+                "BucketDay",
+                "BucketWeek",
+                "BucketMonth"
+            ]
+        },
+        "entity.DeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "delivered",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "DeliveryPending",
+                "DeliveryDelivered",
+                "DeliveryFailed"
+            ]
+        },
+        "entity.Event": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "description": "Actor is subject of principal who made the change, empty if it is unknown",
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "Payload is state of the good after the change",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Good"
+                        }
+                    ]
+                },
+                "previous": {
+                    "description": "Previous is state of the good before the change, nil for created good",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Good"
+                        }
+                    ]
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.EventType"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.EventType": {
+            "type": "string",
+            "enum": [
+                "good.created",
+                "good.updated",
+                "good.deleted",
+                "good.reprioritized",
+                "good.priority_changed",
+                "good.snapshot"
+            ],
+            "x-enum-varnames": [
+                "EventGoodCreated",
+                "EventGoodUpdated",
+                "EventGoodDeleted",
+                "EventGoodReprioritized",
+                "EventGoodPriorityChanged",
+                "EventGoodSnapshot"
+            ]
+        },
         "entity.Good": {
             "type": "object",
             "properties": {
@@ -385,6 +3263,163 @@ const docTemplate = `{
                     "type": "boolean"
                 }
             }
+        },
+        "entity.GoodCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "good_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Role": {
+            "type": "string",
+            "enum": [
+                "viewer",
+                "editor",
+                "admin"
+            ],
+            "x-enum-varnames": [
+                "RoleViewer",
+                "RoleEditor",
+                "RoleAdmin"
+            ]
+        },
+        "entity.SeriesPoint": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "description": "Bucket is start of the time bucket",
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.EventType"
+                }
+            }
+        },
+        "entity.Webhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Enabled is false if webhook is disabled by user or because of failed deliveries",
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "description": "EventTypes filters delivered events, all events are delivered if it is empty",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.EventType"
+                    }
+                },
+                "failures": {
+                    "description": "Failures is count of consecutive failed attempts of delivery",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error of the last attempt",
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "$ref": "#/definitions/entity.EventType"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "response_status": {
+                    "description": "ResponseStatus is HTTP status of the last attempt, 0 if there is no response",
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.DeliveryStatus"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httperror.Body": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is stable code of the error, e.g. ` + "`" + `good_not_found` + "`" + `",
+                    "type": "string",
+                    "example": "validation_failed"
+                },
+                "fields": {
+                    "description": "Fields are details of validation error",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FieldError"
+                    }
+                },
+                "message": {
+                    "description": "Message is human-readable description, it may change",
+                    "type": "string",
+                    "example": "invalid input"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "httperror.Response": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/httperror.Body"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWT with ` + "`" + `Bearer ` + "`" + ` prefix",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
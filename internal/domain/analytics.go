@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+	"goods-manager/internal/domain/entity"
+)
+
+// AnalyticsUsecase represents the use case interface for aggregates of events of goods.
+//
+//go:generate mockery --name AnalyticsUsecase
+type AnalyticsUsecase interface {
+	// TimeSeries counts events of the project by types and time buckets in order of buckets
+	TimeSeries(ctx context.Context, filter entity.AnalyticsFilter) ([]*entity.SeriesPoint, error)
+
+	// TopGoods counts events of the project by goods, goods with the most events first
+	TopGoods(ctx context.Context, filter entity.AnalyticsFilter) ([]*entity.GoodCount, error)
+}
+
+//go:generate mockery --name AnalyticsRepository
+type AnalyticsRepository interface {
+	TimeSeries(ctx context.Context, filter entity.AnalyticsFilter) ([]*entity.SeriesPoint, error)
+	TopGoods(ctx context.Context, filter entity.AnalyticsFilter) ([]*entity.GoodCount, error)
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"goods-manager/internal/domain/entity"
+)
+
+var (
+	ErrorUnauthorized   = NewUnauthorizedError("invalid credentials")
+	ErrorAPIKeyNotFound = NewNotFoundError(CodeAPIKeyNotFound, "api key not found")
+)
+
+type principalKey struct{}
+
+// AuthUsecase represents the use case interface for authentication and API keys management.
+//
+//go:generate mockery --name AuthUsecase
+type AuthUsecase interface {
+	// Authenticate resolves plain API key to principal.
+	// It returns `ErrorUnauthorized` if key is unknown or revoked.
+	Authenticate(ctx context.Context, key string) (*entity.Principal, error)
+
+	// AuthenticateToken validates JWT bearer token and maps its claims to principal with roles.
+	// It returns `ErrorUnauthorized` if token is invalid or tokens are disabled.
+	AuthenticateToken(ctx context.Context, token string) (*entity.Principal, error)
+
+	// CreateKey generates new API key, stores its hash and returns plain key.
+	CreateKey(ctx context.Context, apiKey *entity.APIKey) (string, error)
+
+	// ListKeys retrieves all active API keys.
+	ListKeys(ctx context.Context) ([]*entity.APIKey, error)
+
+	// RevokeKey revokes API key by its ID.
+	RevokeKey(ctx context.Context, id int) error
+}
+
+//go:generate mockery --name APIKeyRepository
+type APIKeyRepository interface {
+	Create(ctx context.Context, apiKey *entity.APIKey) error
+	GetByHash(ctx context.Context, hash string) (*entity.APIKey, error)
+	List(ctx context.Context) ([]*entity.APIKey, error)
+	Revoke(ctx context.Context, id int) error
+}
+
+// Authorize checks that principal from context has `role` in the project
+func Authorize(ctx context.Context, projectId int, role entity.Role) error {
+	principal := PrincipalFromContext(ctx)
+	if principal == nil {
+		return NewUnauthorizedError("unauthorized")
+	}
+
+	if _, ok := principal.Role(projectId); !ok {
+		return NewForbiddenError(CodeProjectForbidden, "project is out of scope")
+	}
+
+	if !principal.Can(projectId, role) {
+		return NewForbiddenError(CodeRoleRequired, "role "+string(role)+" is required")
+	}
+
+	return nil
+}
+
+// AuthorizeAdmin checks that principal from context is admin of all projects
+func AuthorizeAdmin(ctx context.Context) error {
+	principal := PrincipalFromContext(ctx)
+	if principal == nil {
+		return NewUnauthorizedError("unauthorized")
+	}
+
+	if !principal.IsAdmin() {
+		return NewForbiddenError(CodeForbidden, "admin access is required")
+	}
+
+	return nil
+}
+
+// WithPrincipal returns context with authenticated principal
+func WithPrincipal(ctx context.Context, principal *entity.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns principal stored in context or nil if request is not authenticated
+func PrincipalFromContext(ctx context.Context) *entity.Principal {
+	if principal, ok := ctx.Value(principalKey{}).(*entity.Principal); ok {
+		return principal
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "context"
+
+// BackfillUsecase represents the use case interface for restoring of the log from the current state of goods.
+//
+//go:generate mockery --name BackfillUsecase
+type BackfillUsecase interface {
+	// Run writes snapshot events of goods after the checkpoint of the previous run and returns count of written events.
+	// It starts from the first good if `restart` is true.
+	Run(ctx context.Context, restart bool) (int, error)
+}
+
+//go:generate mockery --name CheckpointRepository
+type CheckpointRepository interface {
+	// Get gets the last processed ID of the named process, 0 if it hasn't started
+	Get(ctx context.Context, name string) (int, error)
+
+	// Save sets the last processed ID of the named process
+	Save(ctx context.Context, name string, lastId int) error
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"goods-manager/internal/domain/entity"
+)
+
+var (
+	ErrorDeadLetterNotFound = NewNotFoundError(CodeDeadLetterNotFound, "dead letter not found")
+	ErrorDeadLetterReplayed = NewConflictError(CodeDeadLetterReplayed, "dead letter is already replayed")
+)
+
+// DeadLetterUsecase represents the use case interface for messages which can't be processed.
+//
+//go:generate mockery --name DeadLetterUsecase
+type DeadLetterUsecase interface {
+	// Add stores the message with the reason.
+	Add(ctx context.Context, deadLetter *entity.DeadLetter) error
+
+	// Get retrieves a DeadLetter by its ID.
+	Get(ctx context.Context, id int64) (*entity.DeadLetter, error)
+
+	// List retrieves DeadLetters, the latest first. Replayed ones are omitted unless `replayed` is true.
+	List(ctx context.Context, replayed bool, limit, offset int) ([]*entity.DeadLetter, error)
+
+	// Replay publishes the message again by outbox and marks it replayed.
+	// It returns conflict error if the message is already replayed.
+	Replay(ctx context.Context, id int64) (*entity.DeadLetter, error)
+}
+
+//go:generate mockery --name DeadLetterRepository
+type DeadLetterRepository interface {
+	Create(ctx context.Context, deadLetter *entity.DeadLetter) error
+	Get(ctx context.Context, id int64) (*entity.DeadLetter, error)
+	List(ctx context.Context, replayed bool, limit, offset int) ([]*entity.DeadLetter, error)
+
+	// MarkReplayed sets replay time of not replayed message, it returns false if message is replayed already
+	MarkReplayed(ctx context.Context, deadLetter *entity.DeadLetter) (bool, error)
+}
@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// Bucket is size of time bucket of analytics time series
+type Bucket string
+
+const (
+	BucketHour  Bucket = "hour"
+	BucketDay   Bucket = "day"
+	BucketWeek  Bucket = "week"
+	BucketMonth Bucket = "month"
+)
+
+// AnalyticsFilter selects events of the project for analytics, zero time range is the last `DefaultAnalyticsRange`
+type AnalyticsFilter struct {
+	ProjectId int
+
+	// Types are types of counted events, empty means all types
+	Types []EventType
+
+	// From is inclusive start of time range of events
+	From time.Time
+
+	// To is exclusive end of time range of events
+	To time.Time
+
+	// Bucket is size of buckets of time series
+	Bucket Bucket
+
+	// Limit is count of goods of top
+	Limit int
+}
+
+// DefaultAnalyticsRange is time range of analytics if it isn't set
+const DefaultAnalyticsRange = 30 * 24 * time.Hour
+
+// SeriesPoint is count of events of the type in the time bucket
+type SeriesPoint struct {
+	// Bucket is start of the time bucket
+	Bucket time.Time `json:"bucket"`
+	Type   EventType `json:"type"`
+	Count  uint64    `json:"count"`
+}
+
+// GoodCount is count of events of the good
+type GoodCount struct {
+	GoodId int    `json:"good_id"`
+	Count  uint64 `json:"count"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+// APIKey is a key of machine client scoped to projects.
+// Key has the same role in all its projects.
+//
+// Only hash of the key is stored, plain key is shown once after creation.
+type APIKey struct {
+	Id         int    `json:"id"`
+	Name       string `json:"name"`
+	Prefix     string `json:"prefix"`
+	Hash       string `json:"-"`
+	ProjectIds []int  `json:"project_ids"`
+	Role       Role   `json:"role"`
+	Revoked    bool   `json:"revoked"`
+	CreatedAt  string `json:"created_at"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+// DeadLetter is message of NATS which can't be processed, it is kept with the reason to be inspected and replayed
+type DeadLetter struct {
+	Id      int64               `json:"id"`
+	Subject string              `json:"subject"`
+	Header  map[string][]string `json:"header"`
+	Data    []byte              `json:"-"`
+
+	// Reason is error of the last attempt of processing
+	Reason string `json:"reason"`
+
+	// Attempts is count of attempts of processing before message is dead
+	Attempts  int    `json:"attempts"`
+	CreatedAt string `json:"created_at"`
+
+	// ReplayedAt is time of replay, nil if message is not replayed
+	ReplayedAt *string `json:"replayed_at"`
+}
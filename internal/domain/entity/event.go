@@ -0,0 +1,75 @@
+package entity
+
+import "time"
+
+// EventType is type of good lifecycle event
+type EventType string
+
+const (
+	EventGoodCreated       EventType = "good.created"
+	EventGoodUpdated       EventType = "good.updated"
+	EventGoodDeleted       EventType = "good.deleted"
+	EventGoodReprioritized EventType = "good.reprioritized"
+
+	// EventGoodPriorityChanged is priority shift of good by reprioritization of another good.
+	// It is logged only, webhooks get priorities in `EventGoodReprioritized`.
+	EventGoodPriorityChanged EventType = "good.priority_changed"
+
+	// EventGoodSnapshot is state of good written by backfill of log, it isn't a change of the good
+	EventGoodSnapshot EventType = "good.snapshot"
+)
+
+// Valid reports whether event type is known to webhooks
+func (t EventType) Valid() bool {
+	switch t {
+	case EventGoodCreated, EventGoodUpdated, EventGoodDeleted, EventGoodReprioritized:
+		return true
+	default:
+		return false
+	}
+}
+
+// EventVersion is version of `Event` envelope, it is changed by incompatible changes of the envelope
+const EventVersion = 1
+
+// Event is versioned envelope of good lifecycle event published to logger stream
+type Event struct {
+	Version int       `json:"version"`
+	Id      string    `json:"event_id"`
+	Type    EventType `json:"type"`
+
+	OccurredAt time.Time `json:"occurred_at"`
+
+	// Actor is subject of principal who made the change, empty if it is unknown
+	Actor     string `json:"actor"`
+	ProjectId int    `json:"project_id"`
+
+	// Payload is state of the good after the change
+	Payload *Good `json:"payload"`
+
+	// Previous is state of the good before the change, nil for created good
+	Previous *Good `json:"previous"`
+}
+
+// HistoryFilter selects events of history of the project, zero values don't filter
+type HistoryFilter struct {
+	ProjectId int
+	GoodId    int
+
+	// From is inclusive start of time range of events
+	From time.Time
+
+	// To is exclusive end of time range of events
+	To time.Time
+
+	Limit  int
+	Offset int
+}
+
+// Reprioritization is data of `EventGoodReprioritized`
+type Reprioritization struct {
+	Good *Good `json:"good"`
+
+	// Priorities maps IDs of affected goods to their new priorities
+	Priorities map[int]int `json:"priorities"`
+}
@@ -9,3 +9,24 @@ type Good struct {
 	Removed     bool   `json:"removed"`
 	CreatedAt   string `json:"created_at"`
 }
+
+// GoodPatch is partial update of a good, nil fields are not changed
+type GoodPatch struct {
+	Name        *string
+	Description *string
+}
+
+// Apply sets changed fields of the patch to the good and returns names of these fields
+func (p GoodPatch) Apply(good *Good) []string {
+	var changed []string
+	if p.Name != nil && *p.Name != good.Name {
+		good.Name = *p.Name
+		changed = append(changed, "name")
+	}
+	if p.Description != nil && *p.Description != good.Description {
+		good.Description = *p.Description
+		changed = append(changed, "description")
+	}
+
+	return changed
+}
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGoodPatch_Apply(t *testing.T) {
+	name, description := "name", ""
+	good := Good{Id: 1, Name: "name", Description: "description"}
+
+	changed := GoodPatch{Name: &name, Description: &description}.Apply(&good)
+
+	assert.Equal(t, []string{"description"}, changed)
+	assert.Equal(t, Good{Id: 1, Name: "name"}, good)
+	assert.Empty(t, GoodPatch{}.Apply(&good))
+}
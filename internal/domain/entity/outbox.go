@@ -0,0 +1,13 @@
+package entity
+
+// OutboxMessage is message to NATS stored in transaction of the change and published after commit
+type OutboxMessage struct {
+	Id      int64               `json:"id"`
+	Subject string              `json:"subject"`
+	Header  map[string][]string `json:"header"`
+	Data    []byte              `json:"data"`
+
+	// Attempts is count of claims of the message by relay
+	Attempts  int    `json:"attempts"`
+	CreatedAt string `json:"created_at"`
+}
@@ -0,0 +1,34 @@
+package entity
+
+// Principal is an authenticated caller of API
+type Principal struct {
+	// Subject identifies caller, e.g. `api_key:1` or `user:<sub claim>`
+	Subject string `json:"subject"`
+
+	// Roles maps project ID to role of principal in the project
+	Roles map[int]Role `json:"roles"`
+
+	// AllProjectsRole is a role in every project, empty if there is no such role
+	AllProjectsRole Role `json:"all_projects_role,omitempty"`
+}
+
+// Role returns role of principal in the project, the highest one if there are several
+func (p *Principal) Role(projectId int) (Role, bool) {
+	role, ok := p.Roles[projectId]
+	if p.AllProjectsRole.Includes(role) {
+		role, ok = p.AllProjectsRole, true
+	}
+
+	return role, ok && role.Valid()
+}
+
+// Can reports whether principal has `required` role in the project
+func (p *Principal) Can(projectId int, required Role) bool {
+	role, ok := p.Role(projectId)
+	return ok && role.Includes(required)
+}
+
+// IsAdmin reports whether principal is admin of all projects, it is required for API keys management
+func (p *Principal) IsAdmin() bool {
+	return p.AllProjectsRole == RoleAdmin
+}
@@ -0,0 +1,40 @@
+package entity
+
+// Role is a set of permissions of principal in a project.
+// Every role includes permissions of lower roles.
+type Role string
+
+const (
+	// RoleViewer can read goods
+	RoleViewer Role = "viewer"
+
+	// RoleEditor can create, update and reprioritize goods
+	RoleEditor Role = "editor"
+
+	// RoleAdmin can delete goods
+	RoleAdmin Role = "admin"
+)
+
+// level returns position of role in hierarchy, unknown role has no permissions
+func (r Role) level() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleEditor:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Valid reports whether role is known
+func (r Role) Valid() bool {
+	return r.level() > 0
+}
+
+// Includes reports whether role has all permissions of `required` role
+func (r Role) Includes(required Role) bool {
+	return r.Valid() && r.level() >= required.level()
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// Webhook is subscription of URL to events of goods of a project.
+//
+// Requests are signed by the secret, it is shown once after creation.
+type Webhook struct {
+	Id        int    `json:"id"`
+	ProjectId int    `json:"project_id"`
+	URL       string `json:"url"`
+	Secret    string `json:"-"`
+
+	// EventTypes filters delivered events, all events are delivered if it is empty
+	EventTypes []EventType `json:"event_types"`
+
+	// Enabled is false if webhook is disabled by user or because of failed deliveries
+	Enabled bool `json:"enabled"`
+
+	// Failures is count of consecutive failed attempts of delivery
+	Failures  int    `json:"failures"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Accepts reports whether events of the type are delivered to the webhook
+func (w *Webhook) Accepts(eventType EventType) bool {
+	return len(w.EventTypes) == 0 || slices.Contains(w.EventTypes, eventType)
+}
+
+// WebhookEvent is body of webhook request
+type WebhookEvent struct {
+	Id         string    `json:"id"`
+	Type       EventType `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	ProjectId  int       `json:"project_id"`
+	Data       any       `json:"data"`
+}
+
+// DeliveryStatus is state of webhook delivery
+type DeliveryStatus string
+
+const (
+	// DeliveryPending is delivery which waits for the next attempt
+	DeliveryPending DeliveryStatus = "pending"
+
+	// DeliveryDelivered is delivery accepted by endpoint with 2xx status
+	DeliveryDelivered DeliveryStatus = "delivered"
+
+	// DeliveryFailed is delivery which is not retried anymore
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// WebhookDelivery is delivery of an event to a webhook and result of its last attempt
+type WebhookDelivery struct {
+	Id        int64           `json:"id"`
+	WebhookId int             `json:"webhook_id"`
+	EventId   string          `json:"event_id"`
+	EventType EventType       `json:"event_type"`
+	Payload   json.RawMessage `json:"payload" swaggertype:"object"`
+	Status    DeliveryStatus  `json:"status"`
+	Attempts  int             `json:"attempts"`
+
+	// ResponseStatus is HTTP status of the last attempt, 0 if there is no response
+	ResponseStatus int `json:"response_status,omitempty"`
+
+	// Error of the last attempt
+	Error         string `json:"error,omitempty"`
+	NextAttemptAt string `json:"next_attempt_at"`
+	CreatedAt     string `json:"created_at"`
+
+	// Webhook is target of claimed delivery
+	Webhook *Webhook `json:"-"`
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrorKind classifies domain errors, transport layers map kinds to their statuses
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	KindValidation
+	KindNotFound
+	KindConflict
+	KindForbidden
+	KindUnauthorized
+	KindRateLimited
+)
+
+// Stable codes of errors, clients may rely on them
+const (
+	CodeInternal           = "internal"
+	CodeValidation         = "validation_failed"
+	CodeNotFound           = "not_found"
+	CodeConflict           = "conflict"
+	CodeForbidden          = "forbidden"
+	CodeUnauthorized       = "unauthorized"
+	CodeRateLimited        = "rate_limited"
+	CodeGoodNotFound       = "good_not_found"
+	CodeAPIKeyNotFound     = "api_key_not_found"
+	CodeProjectNotFound    = "project_not_found"
+	CodeProjectNotEmpty    = "project_not_empty"
+	CodeWebhookNotFound    = "webhook_not_found"
+	CodeDeadLetterNotFound = "dead_letter_not_found"
+	CodeDeadLetterReplayed = "dead_letter_replayed"
+	CodeProjectForbidden   = "project_forbidden"
+	CodeRoleRequired       = "role_required"
+
+	CodeIdempotencyKeyReused     = "idempotency_key_reused"
+	CodeIdempotencyKeyInProgress = "idempotency_key_in_progress"
+)
+
+// Stable codes of invalid fields
+const (
+	FieldRequired     = "required"
+	FieldInvalid      = "invalid"
+	FieldTooLong      = "too_long"
+	FieldTooShort     = "too_short"
+	FieldOutOfRange   = "out_of_range"
+	FieldInvalidChars = "invalid_chars"
+)
+
+// FieldError describes invalid field of input
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error is an error of domain with kind, stable code and human-readable message.
+//
+// Errors are compared by code, so errors.Is(err, ErrorGoodNotFound) is true for any error with code `good_not_found`.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+
+	// Fields are details of validation error
+	Fields []FieldError
+
+	// Err is wrapped cause of the error
+	Err error
+}
+
+func (e *Error) Error() string {
+	message := e.Message
+	if len(e.Fields) > 0 {
+		fields := make([]string, len(e.Fields))
+		for i, field := range e.Fields {
+			fields[i] = field.Field + ": " + field.Message
+		}
+		message += " (" + strings.Join(fields, "; ") + ")"
+	}
+
+	if e.Err != nil {
+		return message + ": " + e.Err.Error()
+	}
+
+	return message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	var t *Error
+	if !errors.As(target, &t) {
+		return false
+	}
+
+	return e.Kind == t.Kind && e.Code == t.Code
+}
+
+// Wrap returns copy of the error with cause `err`
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+// NewValidationError creates error of invalid input with details of fields
+func NewValidationError(message string, fields ...FieldError) *Error {
+	return &Error{Kind: KindValidation, Code: CodeValidation, Message: message, Fields: fields}
+}
+
+// NewFieldError creates validation error of one field
+func NewFieldError(field, code, message string) *Error {
+	return NewValidationError("invalid input", FieldError{Field: field, Code: code, Message: message})
+}
+
+func NewNotFoundError(code, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func NewConflictError(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+func NewForbiddenError(code, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+func NewUnauthorizedError(message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: CodeUnauthorized, Message: message}
+}
+
+func NewRateLimitedError(message string) *Error {
+	return &Error{Kind: KindRateLimited, Code: CodeRateLimited, Message: message}
+}
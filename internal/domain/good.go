@@ -2,11 +2,10 @@ package domain
 
 import (
 	"context"
-	"errors"
 	"goods-manager/internal/domain/entity"
 )
 
-var ErrorGoodNotFound = errors.New("good not found")
+var ErrorGoodNotFound = NewNotFoundError(CodeGoodNotFound, "good not found")
 
 // GoodUsecase represents the use case interface for managing goods.
 //
@@ -18,14 +17,22 @@ type GoodUsecase interface {
 	// Get retrieves a Good entity by its ID.
 	Get(ctx context.Context, id int) (*entity.Good, error)
 
+	// GetMany retrieves Good entities by their IDs.
+	// Goods which don't exist are omitted.
+	GetMany(ctx context.Context, ids []int) ([]*entity.Good, error)
+
 	// Update updates an existing Good entity.
 	Update(ctx context.Context, good *entity.Good) error
 
+	// Patch applies changed fields of the patch to an existing Good entity.
+	// The Good is not saved if nothing is changed.
+	Patch(ctx context.Context, good *entity.Good, patch entity.GoodPatch) error
+
 	// Delete deletes an existing Good entity.
 	Delete(ctx context.Context, good *entity.Good) error
 
-	// List retrieves a list of Good entities with pagination support.
-	List(ctx context.Context, limit, offset int) ([]*entity.Good, error)
+	// List retrieves a list of Good entities of the project with pagination support.
+	List(ctx context.Context, projectId, limit, offset int) ([]*entity.Good, error)
 
 	// Reprioritize changes the priority of a Good entity identified by its ID.
 	// It takes a context.Context, ID of the Good, and a new priority as parameters.
@@ -37,9 +44,20 @@ type GoodUsecase interface {
 type GoodRepository interface {
 	Create(ctx context.Context, good *entity.Good) error
 	Get(ctx context.Context, id int) (*entity.Good, error)
+
+	// GetMany gets goods by their IDs, goods which don't exist are omitted.
+	GetMany(ctx context.Context, ids []int) ([]*entity.Good, error)
+
 	Update(ctx context.Context, good *entity.Good) error
+
+	// UpdateFields writes only `fields` (names of `entity.GoodPatch.Apply`) of the good and sets the saved row to the good,
+	// so concurrent changes of other fields are kept.
+	UpdateFields(ctx context.Context, good *entity.Good, fields []string) error
 	Delete(ctx context.Context, id int) error
-	List(ctx context.Context, limit, offset int) ([]*entity.Good, error)
+	List(ctx context.Context, projectId, limit, offset int) ([]*entity.Good, error)
+
+	// ListAfter gets goods of all projects with ID greater than `afterId` in order of IDs, removed goods included.
+	ListAfter(ctx context.Context, afterId, limit int) ([]*entity.Good, error)
 
 	// Reprioritize changes the priority of a good and updates all other priorities.
 	//
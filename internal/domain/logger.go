@@ -6,10 +6,17 @@ import (
 )
 
 type LoggerUsecase interface {
-	SendToQueue(ctx context.Context, good *entity.Good) error
-	SaveList(ctx context.Context, goods []*entity.Good) error
+	// SendToQueue sends event of the change of the good, `previous` is state before the change or nil if good is created
+	SendToQueue(ctx context.Context, eventType entity.EventType, good, previous *entity.Good) error
+	SaveList(ctx context.Context, events []*entity.Event) error
+
+	// ListHistory gets page of events of the project or its good, the latest first
+	ListHistory(ctx context.Context, filter entity.HistoryFilter) ([]*entity.Event, error)
 }
 
 type LoggerRepository interface {
-	SaveList(ctx context.Context, goods []*entity.Good) error
+	SaveList(ctx context.Context, events []*entity.Event) error
+
+	// ListHistory gets page of events without duplicates, the latest first
+	ListHistory(ctx context.Context, filter entity.HistoryFilter) ([]*entity.Event, error)
 }
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"goods-manager/internal/domain/entity"
+	"time"
+)
+
+// OutboxUsecase represents the use case interface for messages published after commit of the transaction.
+//
+//go:generate mockery --name OutboxUsecase
+type OutboxUsecase interface {
+	// Enqueue stores the message to be published.
+	// It is called in transaction of the change, so messages of rolled back changes are not published.
+	Enqueue(ctx context.Context, message *entity.OutboxMessage) error
+
+	// Relay publishes up to `limit` pending messages and returns count of claimed ones.
+	// Message is marked sent after it is published, so it is published at least once.
+	Relay(ctx context.Context, limit int) (int, error)
+
+	// Cleanup deletes up to `limit` messages sent before retention period and returns their count
+	Cleanup(ctx context.Context, limit int) (int, error)
+}
+
+//go:generate mockery --name OutboxRepository
+type OutboxRepository interface {
+	// Create stores pending message and sets its ID
+	Create(ctx context.Context, message *entity.OutboxMessage) error
+
+	// Claim returns due pending messages in order of creation.
+	// Claimed messages are not due for `lease`, so they are not claimed concurrently.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.OutboxMessage, error)
+
+	// MarkSent marks messages as sent, so they are not claimed anymore
+	MarkSent(ctx context.Context, ids []int64) error
+
+	// DeleteSent deletes up to `limit` messages sent before `before` and returns their count
+	DeleteSent(ctx context.Context, before time.Time, limit int) (int, error)
+}
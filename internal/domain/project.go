@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"goods-manager/internal/domain/entity"
+)
+
+var (
+	ErrorProjectNotFound = NewNotFoundError(CodeProjectNotFound, "project not found")
+	ErrorProjectNotEmpty = NewConflictError(CodeProjectNotEmpty, "project has goods")
+)
+
+// ProjectUsecase represents the use case interface for managing projects.
+//
+//go:generate mockery --name ProjectUsecase
+type ProjectUsecase interface {
+	// Create creates a new Project entity.
+	Create(ctx context.Context, project *entity.Project) error
+
+	// Get retrieves a Project entity by its ID.
+	Get(ctx context.Context, id int) (*entity.Project, error)
+
+	// List retrieves all Project entities.
+	List(ctx context.Context) ([]*entity.Project, error)
+
+	// Update updates name of an existing Project entity.
+	Update(ctx context.Context, project *entity.Project) error
+
+	// Delete deletes an existing Project entity.
+	// It returns `ErrorProjectNotEmpty` if the project has goods which are not removed.
+	Delete(ctx context.Context, id int) error
+}
+
+//go:generate mockery --name ProjectRepository
+type ProjectRepository interface {
+	Create(ctx context.Context, project *entity.Project) error
+	Get(ctx context.Context, id int) (*entity.Project, error)
+	List(ctx context.Context) ([]*entity.Project, error)
+	Update(ctx context.Context, project *entity.Project) error
+
+	// Delete removes the project if it has no goods which are not removed
+	Delete(ctx context.Context, id int) error
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"goods-manager/internal/domain/entity"
+	"time"
+)
+
+var ErrorWebhookNotFound = NewNotFoundError(CodeWebhookNotFound, "webhook not found")
+
+// WebhookUsecase represents the use case interface for webhook subscriptions and deliveries.
+//
+//go:generate mockery --name WebhookUsecase
+type WebhookUsecase interface {
+	// Create creates a new Webhook with generated secret.
+	Create(ctx context.Context, webhook *entity.Webhook) error
+
+	// Get retrieves a Webhook by its ID.
+	Get(ctx context.Context, id int) (*entity.Webhook, error)
+
+	// List retrieves Webhooks of the project.
+	List(ctx context.Context, projectId int) ([]*entity.Webhook, error)
+
+	// Update updates URL, event types and state of the Webhook.
+	// Enabled webhook gets zero failures.
+	Update(ctx context.Context, webhook *entity.Webhook) error
+
+	// Delete deletes the Webhook with its deliveries.
+	Delete(ctx context.Context, id int) error
+
+	// ListDeliveries retrieves deliveries of the Webhook, the latest first.
+	ListDeliveries(ctx context.Context, webhookId, limit, offset int) ([]*entity.WebhookDelivery, error)
+
+	// Publish enqueues delivery of the event to enabled webhooks of the project which accept its type.
+	// It is called in transaction of the change, so events of rolled back changes are not delivered.
+	Publish(ctx context.Context, projectId int, eventType entity.EventType, data any) error
+
+	// Dispatch attempts up to `limit` due deliveries and returns count of attempted ones.
+	Dispatch(ctx context.Context, limit int) (int, error)
+}
+
+//go:generate mockery --name WebhookRepository
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *entity.Webhook) error
+	Get(ctx context.Context, id int) (*entity.Webhook, error)
+	List(ctx context.Context, projectId int) ([]*entity.Webhook, error)
+	Update(ctx context.Context, webhook *entity.Webhook) error
+	Delete(ctx context.Context, id int) error
+	ListDeliveries(ctx context.Context, webhookId, limit, offset int) ([]*entity.WebhookDelivery, error)
+
+	// CreateDeliveries creates pending deliveries of the event for enabled webhooks of the project which accept its type
+	CreateDeliveries(ctx context.Context, projectId int, eventId string, eventType entity.EventType, payload []byte) error
+
+	// ClaimDeliveries returns due pending deliveries with their webhooks.
+	// Claimed deliveries are not due for `lease`, so they are not claimed concurrently.
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*entity.WebhookDelivery, error)
+
+	// SaveAttempt saves status, attempts and result of the delivery, pending delivery is due in `retryIn`.
+	// Failures of the webhook are reset by delivered one and counted otherwise, failures of disabled webhook
+	// aren't changed. Webhook is disabled when it has `disableAfter` failures, `disabled` is true in this case.
+	SaveAttempt(ctx context.Context, delivery *entity.WebhookDelivery, retryIn time.Duration, disableAfter int) (disabled bool, err error)
+}
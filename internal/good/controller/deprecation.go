@@ -0,0 +1,20 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"time"
+)
+
+// Deprecated marks responses of deprecated routes by `Deprecation` header (RFC 9745)
+// with the date of deprecation and `Link` header to the successor API.
+func Deprecated(since time.Time, successor string) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(since.Unix(), 10)
+	link := "<" + successor + `>; rel="successor-version"`
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		c.Header("Link", link)
+		c.Next()
+	}
+}
@@ -1,12 +1,11 @@
 package controller
 
 import (
-	"errors"
 	"github.com/gin-gonic/gin"
 	"goods-manager/internal/domain"
 	"goods-manager/internal/domain/entity"
-	"net/http"
-	"strconv"
+	"goods-manager/internal/httperror"
+	"goods-manager/internal/validation"
 )
 
 type GoodController struct {
@@ -16,51 +15,31 @@ type GoodController struct {
 // getGoodFromRequest retrieves a Good entity from the request context.
 //
 // It expects 'id' and 'projectId' query parameters in the request.
-// If any required parameter is missing or if conversion fails, it returns nil.
-// If the Good is not found or if there's an internal server error, appropriate JSON responses are sent.
+// If any parameter is invalid, it returns nil.
+// If the Good is not found or if there's an internal server error, the request is aborted with the error.
 func (g *GoodController) getGoodFromRequest(c *gin.Context) *entity.Good {
-	id, ok := c.GetQuery("id")
-	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "id is required"})
-		return nil
-	}
-	idInt, err := strconv.Atoi(id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var query GoodQuery
+	if !validation.Bind(c, &query, c.ShouldBindQuery) {
 		return nil
 	}
 
-	projectId, ok := c.GetQuery("projectId")
-	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "projectId is required"})
-		return nil
-	}
-	projectIdInt, err := strconv.Atoi(projectId)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return nil
-	}
-
-	goodNotFound := func(details string) {
-		c.JSON(http.StatusNotFound, gin.H{
-			"code":    3,
-			"message": "errors.good.notFound",
-			"details": details,
-		})
-	}
+	return getGood(c, g.goodUsecase, query.Id, query.ProjectId)
+}
 
-	good, err := g.goodUsecase.Get(c, idInt)
+// getGood retrieves a Good entity of the project.
+//
+// If the Good is not found in the project or if there's an internal server error,
+// the request is aborted with the error and nil is returned.
+func getGood(c *gin.Context, goodUsecase domain.GoodUsecase, id, projectId int) *entity.Good {
+	good, err := goodUsecase.Get(c, id)
 	if err != nil {
-		if errors.Is(err, domain.ErrorGoodNotFound) {
-			goodNotFound(err.Error())
-			return nil
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		httperror.Abort(c, err)
 		return nil
 	}
 
-	if good.ProjectId != projectIdInt {
-		goodNotFound("projectId not match")
+	// good of another project is hidden
+	if good.ProjectId != projectId {
+		httperror.Abort(c, domain.ErrorGoodNotFound)
 		return nil
 	}
 
@@ -71,43 +50,40 @@ func (g *GoodController) getGoodFromRequest(c *gin.Context) *entity.Good {
 //
 // @Summary		Add a new good to the store
 // @Tags		good
+// @Deprecated
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
 // @Accept		json
 // @Produce		json
 //
-// @Param		projectId	query		int				true	"Project ID"
-// @Param		good	body		entity.Good			true	"Good object that needs to be added to the store"
+// @Param		projectId	query		int				true	"Project ID"	minimum(1)
+// @Param		good	body		GoodRequest			true	"Good object that needs to be added to the store"
+// @Param		Idempotency-Key	header	string	false	"Key to retry request safely"
 //
 // @Success		200		{object}	entity.Good			"Good object that was added"
-// @Failure		400		{string}	string				"Invalid input"
-// @Failure		500		{string}	string				"Server error"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response	"Project not found or removed"
+// @Failure		409		{object}	httperror.Response	"Idempotency-Key is reused or in progress"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
 // @Router		/good/create 	[post]
 func (g *GoodController) Create(c *gin.Context) {
-	var good entity.Good
-	if err := c.BindJSON(&good); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var request GoodRequest
+	if !validation.Bind(c, &request, c.ShouldBindJSON) {
 		return
 	}
 
-	if good.Name == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+	var query ProjectQuery
+	if !validation.Bind(c, &query, c.ShouldBindQuery) {
 		return
 	}
 
-	// if exists projectId param, then set it
-	projectId, ok := c.GetQuery("projectId")
-	if ok {
-		projectIdInt, err := strconv.Atoi(projectId)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-
-		good.ProjectId = projectIdInt
-	}
-
+	good := entity.Good{ProjectId: query.ProjectId, Name: request.Name, Description: request.Description}
 	err := g.goodUsecase.Create(c, &good)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		httperror.Abort(c, err)
 		return
 	}
 
@@ -118,76 +94,68 @@ func (g *GoodController) Create(c *gin.Context) {
 //
 // @Summary		Get list goods
 // @Tags		good
+// @Deprecated
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
 // @Accept		json
 // @Produce		json
 //
-// @Param		offset	query		int				true	"Offset of select"
-// @Param		limit	query		int				true	"Limit of rows"
+// @Param		projectId	query		int			true	"Project ID"	minimum(1)
+// @Param		offset	query		int				false	"Offset of select"	minimum(0)	default(1)
+// @Param		limit	query		int				false	"Limit of rows"		minimum(1)	maximum(100)	default(10)
 //
 // @Success		200		{object}	ListResponse		"Goods objects and metadata"
-// @Failure		400		{string}	string				"Invalid input"
-// @Failure		500		{string}	string				"Server error"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
 // @Router		/good/list			[get]
 func (g *GoodController) List(c *gin.Context) {
-	limit := 10
-	limitQuery, ok := c.GetQuery("limit")
-	if ok {
-		limitInt, err := strconv.Atoi(limitQuery)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-
-		limit = limitInt
-	}
-
-	offset := 1
-	offsetQuery, ok := c.GetQuery("offset")
-	if ok {
-		offsetInt, err := strconv.Atoi(offsetQuery)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-
-		offset = offsetInt
+	var query ListQuery
+	if !validation.Bind(c, &query, c.ShouldBindQuery) {
+		return
 	}
 
-	goods, err := g.goodUsecase.List(c, limit, offset)
+	goods, err := g.goodUsecase.List(c, query.ProjectId, query.Limit, query.Offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		httperror.Abort(c, err)
 		return
 	}
 
-	meta := MetaFromGoods(goods, limit, offset)
+	meta := MetaFromGoods(goods, query.Limit, query.Offset)
 	c.JSON(200, ListResponse{Meta: meta, Goods: goods})
 }
 
-// Update this function update good.
+// Update this function update good by JSON merge patch.
 //
 // @Summary		Update good
+// @Description	Body is JSON merge patch (RFC 7396): omitted fields are not changed, `null` clears description.
 // @Tags		good
+// @Deprecated
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
 // @Accept		json
+// @Accept		application/merge-patch+json
 // @Produce		json
 //
-// @Param		projectId	query		int				true	"Project ID"
-// @Param		id			query		int				true	"ID of good"
-// @Param		good		body		entity.Good		true	"Good object that needs update"
+// @Param		projectId	query		int				true	"Project ID"	minimum(1)
+// @Param		id			query		int				true	"ID of good"	minimum(1)
+// @Param		good		body		PatchGoodRequest	true	"Changed fields of the good"
+// @Param		Idempotency-Key	header	string	false	"Key to retry request safely"
 //
 // @Success		200		{object}	entity.Good			"Good that was updated"
-// @Failure		400		{string}	string				"Invalid input"
-// @Failure		404		{string}	string				"Good not found"
-// @Failure		500		{string}	string				"Server error"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response	"Good not found"
+// @Failure		409		{object}	httperror.Response	"Idempotency-Key is reused or in progress"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
 // @Router		/good/update		[patch]
 func (g *GoodController) Update(c *gin.Context) {
-	var goodUpdate entity.Good
-	if err := c.BindJSON(&goodUpdate); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	if goodUpdate.Name == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+	patch, ok := bindGoodPatch(c)
+	if !ok {
 		return
 	}
 
@@ -196,14 +164,9 @@ func (g *GoodController) Update(c *gin.Context) {
 		return
 	}
 
-	good.Name = goodUpdate.Name
-	if goodUpdate.Description != "" {
-		good.Description = goodUpdate.Description
-	}
-
-	err := g.goodUsecase.Update(c, good)
+	err := g.goodUsecase.Patch(c, good, patch)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		httperror.Abort(c, err)
 		return
 	}
 
@@ -214,15 +177,23 @@ func (g *GoodController) Update(c *gin.Context) {
 //
 // @Summary		Delete good
 // @Tags		good
+// @Deprecated
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
 // @Produce		json
 //
-// @Param		projectId	query		int				true	"Project ID"
-// @Param		id			query		int				true	"ID of good"
+// @Param		projectId	query		int				true	"Project ID"	minimum(1)
+// @Param		id			query		int				true	"ID of good"	minimum(1)
+// @Param		Idempotency-Key	header	string	false	"Key to retry request safely"
 //
 // @Success		200		{object}	entity.Good			"Good that was deleted"
-// @Failure		400		{string}	string				"Invalid input"
-// @Failure		404		{string}	string				"Good not found"
-// @Failure		500		{string}	string				"Server error"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response	"Good not found"
+// @Failure		409		{object}	httperror.Response	"Idempotency-Key is reused or in progress"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
 // @Router		/good/remove		[delete]
 func (g *GoodController) Delete(c *gin.Context) {
 	good := g.getGoodFromRequest(c)
@@ -232,7 +203,7 @@ func (g *GoodController) Delete(c *gin.Context) {
 
 	err := g.goodUsecase.Delete(c, good)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		httperror.Abort(c, err)
 		return
 	}
 
@@ -243,26 +214,28 @@ func (g *GoodController) Delete(c *gin.Context) {
 //
 // @Summary		Reprioritize good priority
 // @Tags		good
+// @Deprecated
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
 // @Produce		json
 //
-// @Param		projectId	query		int				true	"Project ID"
-// @Param		id			query		int				true	"ID of good"
+// @Param		projectId	query		int				true	"Project ID"	minimum(1)
+// @Param		id			query		int				true	"ID of good"	minimum(1)
 // @Param		good		body		PrioritizeRequest		true	"New priority"
+// @Param		Idempotency-Key	header	string	false	"Key to retry request safely"
 //
 // @Success		200		{object}	PrioritizeResponse	"List goods where was update priority"
-// @Failure		400		{string}	string				"Invalid input"
-// @Failure		404		{string}	string				"Good not found"
-// @Failure		500		{string}	string				"Server error"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response	"Good not found"
+// @Failure		409		{object}	httperror.Response	"Idempotency-Key is reused or in progress"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
 // @Router		/good/reprioritiize		[patch]
 func (g *GoodController) Reprioritize(c *gin.Context) {
 	var priorityRequest PrioritizeRequest
-	if err := c.BindJSON(&priorityRequest); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	if priorityRequest.NewPriority < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "newPriority must be greater than 0"})
+	if !validation.Bind(c, &priorityRequest, c.ShouldBindJSON) {
 		return
 	}
 
@@ -273,7 +246,7 @@ func (g *GoodController) Reprioritize(c *gin.Context) {
 
 	newPriorities, err := g.goodUsecase.Reprioritize(c, good.Id, priorityRequest.NewPriority)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		httperror.Abort(c, err)
 		return
 	}
 
@@ -281,6 +254,11 @@ func (g *GoodController) Reprioritize(c *gin.Context) {
 	c.JSON(200, resp)
 }
 
+// invalidParam aborts request with validation error of parameter `name`
+func invalidParam(c *gin.Context, name, code, message string) {
+	httperror.Abort(c, domain.NewFieldError(name, code, message))
+}
+
 func NewGoodController(goodUsecase domain.GoodUsecase) *GoodController {
 	return &GoodController{goodUsecase: goodUsecase}
 }
@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin/binding"
+	goodsv1 "goods-manager/api/goods/v1"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/validation"
+	"sort"
+)
+
+// listPageSize is size of pages read to stream all goods of a project
+const listPageSize = 100
+
+// GoodGRPCController serves `goods.v1.GoodsService`.
+//
+// Roles are checked by handlers as `RequireRole` does for HTTP routes,
+// input is validated by the same rules as HTTP requests.
+type GoodGRPCController struct {
+	goodsv1.UnimplementedGoodsServiceServer
+
+	goodUsecase domain.GoodUsecase
+}
+
+// getGood retrieves a Good entity of the project, good of another project is not found
+func (g *GoodGRPCController) getGood(ctx context.Context, projectId, id int32) (*entity.Good, error) {
+	if err := validate(&GoodPath{ProjectId: int(projectId), Id: int(id)}); err != nil {
+		return nil, err
+	}
+
+	good, err := g.goodUsecase.Get(ctx, int(id))
+	if err != nil {
+		return nil, err
+	}
+
+	if good.ProjectId != int(projectId) {
+		return nil, domain.ErrorGoodNotFound
+	}
+
+	return good, nil
+}
+
+func (g *GoodGRPCController) CreateGood(ctx context.Context, req *goodsv1.CreateGoodRequest) (*goodsv1.Good, error) {
+	if err := domain.Authorize(ctx, int(req.ProjectId), entity.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	if err := validate(&GoodRequest{Name: req.Name, Description: req.Description}); err != nil {
+		return nil, err
+	}
+
+	good := entity.Good{ProjectId: int(req.ProjectId), Name: req.Name, Description: req.Description}
+	if err := g.goodUsecase.Create(ctx, &good); err != nil {
+		return nil, err
+	}
+
+	return goodMessage(&good), nil
+}
+
+func (g *GoodGRPCController) GetGood(ctx context.Context, req *goodsv1.GetGoodRequest) (*goodsv1.Good, error) {
+	if err := domain.Authorize(ctx, int(req.ProjectId), entity.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	good, err := g.getGood(ctx, req.ProjectId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return goodMessage(good), nil
+}
+
+// ListGoods streams goods of the project page by page, all goods are streamed if limit is not set
+func (g *GoodGRPCController) ListGoods(req *goodsv1.ListGoodsRequest, stream goodsv1.GoodsService_ListGoodsServer) error {
+	ctx := stream.Context()
+	if err := domain.Authorize(ctx, int(req.ProjectId), entity.RoleViewer); err != nil {
+		return err
+	}
+
+	var fields []domain.FieldError
+	if req.Limit < 0 {
+		fields = append(fields, domain.FieldError{Field: "limit", Code: domain.FieldOutOfRange, Message: "limit must be at least 0"})
+	}
+	if req.Offset < 0 {
+		fields = append(fields, domain.FieldError{Field: "offset", Code: domain.FieldOutOfRange, Message: "offset must be at least 0"})
+	}
+	if len(fields) > 0 {
+		return domain.NewValidationError("invalid input", fields...)
+	}
+
+	limit, offset := int(req.Limit), int(req.Offset)
+	for sent := 0; limit == 0 || sent < limit; {
+		pageSize := listPageSize
+		if limit > 0 && limit-sent < pageSize {
+			pageSize = limit - sent
+		}
+
+		goods, err := g.goodUsecase.List(ctx, int(req.ProjectId), pageSize, offset+sent)
+		if err != nil {
+			return err
+		}
+
+		for _, good := range goods {
+			if err := stream.Send(goodMessage(good)); err != nil {
+				return err
+			}
+		}
+
+		sent += len(goods)
+		if len(goods) < pageSize {
+			break
+		}
+	}
+
+	return nil
+}
+
+// UpdateGood changes set fields of the good as merge patch does
+func (g *GoodGRPCController) UpdateGood(ctx context.Context, req *goodsv1.UpdateGoodRequest) (*goodsv1.Good, error) {
+	if err := domain.Authorize(ctx, int(req.ProjectId), entity.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	patch := entity.GoodPatch{Name: req.Name, Description: req.Description}
+	if err := validate(&PatchGoodRequest{Name: patch.Name, Description: patch.Description}); err != nil {
+		return nil, err
+	}
+
+	good, err := g.getGood(ctx, req.ProjectId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.goodUsecase.Patch(ctx, good, patch); err != nil {
+		return nil, err
+	}
+
+	return goodMessage(good), nil
+}
+
+func (g *GoodGRPCController) DeleteGood(ctx context.Context, req *goodsv1.DeleteGoodRequest) (*goodsv1.Good, error) {
+	if err := domain.Authorize(ctx, int(req.ProjectId), entity.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	good, err := g.getGood(ctx, req.ProjectId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.goodUsecase.Delete(ctx, good); err != nil {
+		return nil, err
+	}
+
+	return goodMessage(good), nil
+}
+
+func (g *GoodGRPCController) ReprioritizeGood(ctx context.Context, req *goodsv1.ReprioritizeGoodRequest) (*goodsv1.ReprioritizeGoodResponse, error) {
+	if err := domain.Authorize(ctx, int(req.ProjectId), entity.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	if err := validate(&PrioritizeRequest{NewPriority: int(req.NewPriority)}); err != nil {
+		return nil, err
+	}
+
+	good, err := g.getGood(ctx, req.ProjectId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	priorities, err := g.goodUsecase.Reprioritize(ctx, good.Id, int(req.NewPriority))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &goodsv1.ReprioritizeGoodResponse{Priorities: make([]*goodsv1.Priority, 0, len(priorities))}
+	for id, priority := range priorities {
+		resp.Priorities = append(resp.Priorities, &goodsv1.Priority{Id: int32(id), Priority: int32(priority)})
+	}
+	sort.Slice(resp.Priorities, func(i, j int) bool { return resp.Priorities[i].Id < resp.Priorities[j].Id })
+
+	return resp, nil
+}
+
+// validate validates request by rules of its `binding` tags
+func validate(obj any) error {
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		return validation.Error(err)
+	}
+
+	return nil
+}
+
+func goodMessage(good *entity.Good) *goodsv1.Good {
+	return &goodsv1.Good{
+		Id:          int32(good.Id),
+		ProjectId:   int32(good.ProjectId),
+		Name:        good.Name,
+		Description: good.Description,
+		Priority:    int32(good.Priority),
+		Removed:     good.Removed,
+		CreatedAt:   good.CreatedAt,
+	}
+}
+
+func NewGoodGRPCController(goodUsecase domain.GoodUsecase) *GoodGRPCController {
+	return &GoodGRPCController{goodUsecase: goodUsecase}
+}
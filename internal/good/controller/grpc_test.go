@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	goodsv1 "goods-manager/api/goods/v1"
+	controller2 "goods-manager/internal/auth/controller"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/grpcerror"
+	"goods-manager/internal/validation"
+	"goods-manager/mocks"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+)
+
+func newGRPCClient(t *testing.T, goodUsecase *mocks.GoodUsecase) goodsv1.GoodsServiceClient {
+	require.NoError(t, validation.Register())
+
+	authUsecase := mocks.NewAuthUsecase(t)
+	authUsecase.On("Authenticate", mock.Anything, "editor").
+		Return(&entity.Principal{Subject: "api_key:1", Roles: map[int]entity.Role{2: entity.RoleEditor}}, nil).Maybe()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcerror.UnaryServerInterceptor(logger), controller2.UnaryAuthenticate(authUsecase)),
+		grpc.ChainStreamInterceptor(grpcerror.StreamServerInterceptor(logger), controller2.StreamAuthenticate(authUsecase)),
+	)
+	goodsv1.RegisterGoodsServiceServer(server, NewGoodGRPCController(goodUsecase))
+
+	listener := bufconn.Listen(1024 * 1024)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return goodsv1.NewGoodsServiceClient(conn)
+}
+
+func editorContext() context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "editor")
+}
+
+func TestGoodGRPCController_Unauthenticated(t *testing.T) {
+	client := newGRPCClient(t, mocks.NewGoodUsecase(t))
+
+	_, err := client.GetGood(context.Background(), &goodsv1.GetGoodRequest{ProjectId: 2, Id: 5})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestGoodGRPCController_GetOtherProject(t *testing.T) {
+	goodUsecase := mocks.NewGoodUsecase(t)
+	goodUsecase.On("Get", mock.Anything, 5).Return(&entity.Good{Id: 5, ProjectId: 3}, nil)
+	client := newGRPCClient(t, goodUsecase)
+
+	_, err := client.GetGood(editorContext(), &goodsv1.GetGoodRequest{ProjectId: 2, Id: 5})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "good_not_found", st.Details()[0].(*errdetails.ErrorInfo).Reason)
+}
+
+func TestGoodGRPCController_CreateInvalid(t *testing.T) {
+	client := newGRPCClient(t, mocks.NewGoodUsecase(t))
+
+	_, err := client.CreateGood(editorContext(), &goodsv1.CreateGoodRequest{ProjectId: 2, Name: " "})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 2)
+	assert.Equal(t, "name", st.Details()[1].(*errdetails.BadRequest).FieldViolations[0].Field)
+}
+
+func TestGoodGRPCController_ListGoods(t *testing.T) {
+	page := make([]*entity.Good, listPageSize)
+	for i := range page {
+		page[i] = &entity.Good{Id: i + 1, ProjectId: 2}
+	}
+
+	goodUsecase := mocks.NewGoodUsecase(t)
+	goodUsecase.On("List", mock.Anything, 2, listPageSize, 0).Return(page, nil)
+	goodUsecase.On("List", mock.Anything, 2, listPageSize, listPageSize).Return([]*entity.Good{{Id: 101, ProjectId: 2}}, nil)
+	client := newGRPCClient(t, goodUsecase)
+
+	stream, err := client.ListGoods(editorContext(), &goodsv1.ListGoodsRequest{ProjectId: 2})
+	require.NoError(t, err)
+
+	count := 0
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		count++
+	}
+
+	assert.Equal(t, listPageSize+1, count)
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/httperror"
+	"goods-manager/internal/validation"
+)
+
+// bindGoodPatch binds JSON merge patch (RFC 7396) of a good from body of the request.
+//
+// Omitted fields are not changed, `null` clears description and is not allowed for name.
+// If the patch is invalid, the request is aborted with validation error.
+func bindGoodPatch(c *gin.Context) (entity.GoodPatch, bool) {
+	body, err := c.GetRawData()
+	if err != nil {
+		httperror.Abort(c, domain.NewValidationError("failed read body").Wrap(err))
+		return entity.GoodPatch{}, false
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		httperror.Abort(c, domain.NewValidationError("body is required"))
+		return entity.GoodPatch{}, false
+	}
+
+	// fields are decoded as is to tell `null` from omitted field
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		httperror.Abort(c, domain.NewValidationError("body must be JSON object"))
+		return entity.GoodPatch{}, false
+	}
+
+	var request PatchGoodRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		httperror.Abort(c, validation.Error(err))
+		return entity.GoodPatch{}, false
+	}
+	if err := binding.Validator.ValidateStruct(&request); err != nil {
+		httperror.Abort(c, validation.Error(err))
+		return entity.GoodPatch{}, false
+	}
+
+	if isNull(fields["name"]) {
+		invalidParam(c, "name", domain.FieldRequired, "name can't be removed")
+		return entity.GoodPatch{}, false
+	}
+
+	patch := entity.GoodPatch{Name: request.Name, Description: request.Description}
+	if isNull(fields["description"]) {
+		patch.Description = new(string)
+	}
+
+	return patch, true
+}
+
+// isNull reports whether value of the field is `null`
+func isNull(value json.RawMessage) bool {
+	return value != nil && string(bytes.TrimSpace(value)) == "null"
+}
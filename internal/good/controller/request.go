@@ -1,5 +1,55 @@
 package controller
 
 type PrioritizeRequest struct {
-	NewPriority int `json:"newPriority"`
+	NewPriority int `json:"newPriority" binding:"required,min=1" minimum:"1"`
+}
+
+// GoodRequest is body of creating and replacing a good
+type GoodRequest struct {
+	Name        string `json:"name" binding:"required,notblank,max=255,singleline" minLength:"1" maxLength:"255"`
+	Description string `json:"description" binding:"max=255,multiline" maxLength:"255"`
+}
+
+// PatchGoodRequest is JSON merge patch (RFC 7396) of a good, omitted fields are not changed
+type PatchGoodRequest struct {
+	// Name can't be removed by `null`
+	Name *string `json:"name" binding:"omitempty,notblank,max=255,singleline" minLength:"1" maxLength:"255"`
+
+	// Description is cleared by `null`
+	Description *string `json:"description" binding:"omitempty,max=255,multiline" maxLength:"255" extensions:"x-nullable"`
+}
+
+// GoodQuery is query of v1 routes of a good
+type GoodQuery struct {
+	ProjectId int `form:"projectId" binding:"required,min=1"`
+	Id        int `form:"id" binding:"required,min=1"`
+}
+
+// ProjectQuery is query of v1 routes of a project
+type ProjectQuery struct {
+	ProjectId int `form:"projectId" binding:"required,min=1"`
+}
+
+// ListQuery is query of v1 list, default offset is 1 for compatibility
+type ListQuery struct {
+	ProjectId int `form:"projectId" binding:"required,min=1"`
+	Limit     int `form:"limit,default=10" binding:"min=1,max=100"`
+	Offset    int `form:"offset,default=1" binding:"min=0"`
+}
+
+// GoodPath is path of v2 routes of a good
+type GoodPath struct {
+	ProjectId int `uri:"projectId" binding:"required,min=1"`
+	Id        int `uri:"id" binding:"required,min=1"`
+}
+
+// ProjectPath is path of v2 routes of a project
+type ProjectPath struct {
+	ProjectId int `uri:"projectId" binding:"required,min=1"`
+}
+
+// PageQuery is pagination of v2 list
+type PageQuery struct {
+	Limit  int `form:"limit,default=10" binding:"min=1,max=100"`
+	Offset int `form:"offset,default=0" binding:"min=0"`
 }
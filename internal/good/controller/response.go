@@ -48,3 +48,29 @@ func PrioritizeResponseFromMap(prioritize map[int]int) *PrioritizeResponse {
 
 	return &PrioritizeResponse{Priorities: priorities}
 }
+
+type BatchResponse struct {
+	Goods   []*entity.Good `json:"goods"`
+	Missing []int          `json:"missing"`
+}
+
+// BatchResponseFromGoods orders goods of the project by `ids`, IDs of other goods are missing
+func BatchResponseFromGoods(ids []int, goods []*entity.Good, projectId int) *BatchResponse {
+	byId := make(map[int]*entity.Good, len(goods))
+	for _, good := range goods {
+		if good.ProjectId == projectId {
+			byId[good.Id] = good
+		}
+	}
+
+	resp := &BatchResponse{Goods: make([]*entity.Good, 0, len(goods)), Missing: make([]int, 0)}
+	for _, id := range ids {
+		if good, ok := byId[id]; ok {
+			resp.Goods = append(resp.Goods, good)
+		} else {
+			resp.Missing = append(resp.Missing, id)
+		}
+	}
+
+	return resp
+}
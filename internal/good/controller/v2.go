@@ -0,0 +1,382 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/httperror"
+	"goods-manager/internal/validation"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxBatchSize is max count of IDs in batch get
+const maxBatchSize = 100
+
+// GoodControllerV2 serves goods of a project by RESTful routes `/v2/projects/:projectId/goods[/:id]`
+type GoodControllerV2 struct {
+	goodUsecase domain.GoodUsecase
+}
+
+// getGoodFromPath retrieves a Good entity by `projectId` and `id` path parameters.
+// If the Good can't be retrieved, the request is aborted with the error and nil is returned.
+func (g *GoodControllerV2) getGoodFromPath(c *gin.Context) *entity.Good {
+	var path GoodPath
+	if !validation.Bind(c, &path, c.ShouldBindUri) {
+		return nil
+	}
+
+	return getGood(c, g.goodUsecase, path.Id, path.ProjectId)
+}
+
+// Create this function is used to create a good in the project.
+//
+// @Summary		Add a new good to the project
+// @Tags		goods v2
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Accept		json
+// @Produce		json
+//
+// @Param		projectId		path		int				true	"Project ID"	minimum(1)
+// @Param		good			body		GoodRequest		true	"Good that needs to be added"
+// @Param		Idempotency-Key	header		string			false	"Key to retry request safely"
+//
+// @Success		201		{object}	entity.Good			"Good that was added"
+// @Header		201		{string}	Location			"URL of the good"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response	"Project not found or removed"
+// @Failure		409		{object}	httperror.Response	"Idempotency-Key is reused or in progress"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/goods		[post]
+func (g *GoodControllerV2) Create(c *gin.Context) {
+	var path ProjectPath
+	if !validation.Bind(c, &path, c.ShouldBindUri) {
+		return
+	}
+
+	var request GoodRequest
+	if !validation.Bind(c, &request, c.ShouldBindJSON) {
+		return
+	}
+
+	good := entity.Good{ProjectId: path.ProjectId, Name: request.Name, Description: request.Description}
+	if err := g.goodUsecase.Create(c, &good); err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.Header("Location", c.Request.URL.Path+"/"+strconv.Itoa(good.Id))
+	c.JSON(http.StatusCreated, good)
+}
+
+// List this function is used for get goods of the project.
+//
+// @Summary		Get list goods of the project
+// @Tags		goods v2
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		projectId	path		int				true	"Project ID"	minimum(1)
+// @Param		offset		query		int				false	"Offset of select"	minimum(0)	default(0)
+// @Param		limit		query		int				false	"Limit of rows"		minimum(1)	maximum(100)	default(10)
+//
+// @Success		200		{object}	ListResponse		"Goods objects and metadata"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/goods		[get]
+func (g *GoodControllerV2) List(c *gin.Context) {
+	var path ProjectPath
+	if !validation.Bind(c, &path, c.ShouldBindUri) {
+		return
+	}
+
+	var page PageQuery
+	if !validation.Bind(c, &page, c.ShouldBindQuery) {
+		return
+	}
+
+	goods, err := g.goodUsecase.List(c, path.ProjectId, page.Limit, page.Offset)
+	if err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListResponse{Meta: MetaFromGoods(goods, page.Limit, page.Offset), Goods: goods})
+}
+
+// Get this function is used for get good of the project.
+//
+// @Summary		Get good
+// @Tags		goods v2
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		projectId	path		int				true	"Project ID"	minimum(1)
+// @Param		id			path		int				true	"ID of good"	minimum(1)
+//
+// @Success		200		{object}	entity.Good			"Good object"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response	"Good not found"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/goods/{id}		[get]
+func (g *GoodControllerV2) Get(c *gin.Context) {
+	good := g.getGoodFromPath(c)
+	if good == nil {
+		return
+	}
+
+	c.JSON(http.StatusOK, good)
+}
+
+// BatchGet this function is used for get goods of the project by list of IDs.
+//
+// @Summary		Get goods by IDs
+// @Tags		goods v2
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		projectId	path		int				true	"Project ID"	minimum(1)
+// @Param		ids			query		[]int			true	"IDs of goods, max 100"	collectionFormat(csv)
+//
+// @Success		200		{object}	BatchResponse		"Found goods in order of IDs and IDs which are not found"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/goods/batch		[get]
+func (g *GoodControllerV2) BatchGet(c *gin.Context) {
+	var path ProjectPath
+	if !validation.Bind(c, &path, c.ShouldBindUri) {
+		return
+	}
+
+	ids, err := parseIds(c.Query("ids"))
+	if err != nil {
+		invalidParam(c, "ids", domain.FieldInvalid, err.Error())
+		return
+	}
+
+	goods, err := g.goodUsecase.GetMany(c, ids)
+	if err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, BatchResponseFromGoods(ids, goods, path.ProjectId))
+}
+
+// parseIds parses comma separated unique IDs
+func parseIds(s string) ([]int, error) {
+	if s == "" {
+		return nil, errors.New("ids is required")
+	}
+
+	parts := strings.Split(s, ",")
+	if len(parts) > maxBatchSize {
+		return nil, fmt.Errorf("count of ids must be at most %d", maxBatchSize)
+	}
+
+	ids := make([]int, 0, len(parts))
+	seen := make(map[int]bool, len(parts))
+	for _, part := range parts {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q", part)
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// Replace this function replaces name and description of the good.
+//
+// @Summary		Replace good
+// @Tags		goods v2
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Accept		json
+// @Produce		json
+//
+// @Param		projectId		path		int				true	"Project ID"	minimum(1)
+// @Param		id				path		int				true	"ID of good"	minimum(1)
+// @Param		good			body		GoodRequest		true	"New state of the good"
+// @Param		Idempotency-Key	header		string			false	"Key to retry request safely"
+//
+// @Success		200		{object}	entity.Good			"Good that was updated"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response	"Good not found"
+// @Failure		409		{object}	httperror.Response	"Idempotency-Key is reused or in progress"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/goods/{id}		[put]
+func (g *GoodControllerV2) Replace(c *gin.Context) {
+	var request GoodRequest
+	if !validation.Bind(c, &request, c.ShouldBindJSON) {
+		return
+	}
+
+	good := g.getGoodFromPath(c)
+	if good == nil {
+		return
+	}
+
+	good.Name = request.Name
+	good.Description = request.Description
+
+	if err := g.goodUsecase.Update(c, good); err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, good)
+}
+
+// Patch this function updates only passed fields of the good by JSON merge patch.
+//
+// @Summary		Update good partially
+// @Description	Body is JSON merge patch (RFC 7396): omitted fields are not changed, `null` clears description.
+// @Tags		goods v2
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Accept		json
+// @Accept		application/merge-patch+json
+// @Produce		json
+//
+// @Param		projectId		path		int					true	"Project ID"	minimum(1)
+// @Param		id				path		int					true	"ID of good"	minimum(1)
+// @Param		good			body		PatchGoodRequest	true	"Changed fields of the good"
+// @Param		Idempotency-Key	header		string				false	"Key to retry request safely"
+//
+// @Success		200		{object}	entity.Good			"Good that was updated"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response	"Good not found"
+// @Failure		409		{object}	httperror.Response	"Idempotency-Key is reused or in progress"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/goods/{id}		[patch]
+func (g *GoodControllerV2) Patch(c *gin.Context) {
+	patch, ok := bindGoodPatch(c)
+	if !ok {
+		return
+	}
+
+	good := g.getGoodFromPath(c)
+	if good == nil {
+		return
+	}
+
+	if err := g.goodUsecase.Patch(c, good, patch); err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, good)
+}
+
+// Delete this function delete good.
+//
+// @Summary		Delete good
+// @Tags		goods v2
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		projectId		path		int				true	"Project ID"	minimum(1)
+// @Param		id				path		int				true	"ID of good"	minimum(1)
+// @Param		Idempotency-Key	header		string			false	"Key to retry request safely"
+//
+// @Success		200		{object}	entity.Good			"Good that was deleted"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response	"Good not found"
+// @Failure		409		{object}	httperror.Response	"Idempotency-Key is reused or in progress"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/goods/{id}		[delete]
+func (g *GoodControllerV2) Delete(c *gin.Context) {
+	good := g.getGoodFromPath(c)
+	if good == nil {
+		return
+	}
+
+	if err := g.goodUsecase.Delete(c, good); err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, good)
+}
+
+// Move this function changes priority of the good.
+//
+// @Summary		Move good to new priority
+// @Tags		goods v2
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Accept		json
+// @Produce		json
+//
+// @Param		projectId		path		int					true	"Project ID"	minimum(1)
+// @Param		id				path		int					true	"ID of good"	minimum(1)
+// @Param		good			body		PrioritizeRequest	true	"New priority"
+// @Param		Idempotency-Key	header		string				false	"Key to retry request safely"
+//
+// @Success		200		{object}	PrioritizeResponse	"List goods where was update priority"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response	"Good not found"
+// @Failure		409		{object}	httperror.Response	"Idempotency-Key is reused or in progress"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/goods/{id}/move		[post]
+func (g *GoodControllerV2) Move(c *gin.Context) {
+	var request PrioritizeRequest
+	if !validation.Bind(c, &request, c.ShouldBindJSON) {
+		return
+	}
+
+	good := g.getGoodFromPath(c)
+	if good == nil {
+		return
+	}
+
+	newPriorities, err := g.goodUsecase.Reprioritize(c, good.Id, request.NewPriority)
+	if err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, PrioritizeResponseFromMap(newPriorities))
+}
+
+func NewGoodControllerV2(goodUsecase domain.GoodUsecase) *GoodControllerV2 {
+	return &GoodControllerV2{goodUsecase: goodUsecase}
+}
@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/httperror"
+	"goods-manager/internal/validation"
+	"goods-manager/mocks"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newV2Router(goodUsecase domain.GoodUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	if err := validation.Register(); err != nil {
+		panic(err)
+	}
+
+	goodController := NewGoodControllerV2(goodUsecase)
+
+	r := gin.New()
+	r.Use(httperror.Middleware(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	goodR := r.Group("/v2/projects/:projectId/goods")
+	goodR.POST("", goodController.Create)
+	goodR.GET("/batch", goodController.BatchGet)
+	goodR.GET("/:id", goodController.Get)
+	goodR.PATCH("/:id", goodController.Patch)
+	goodR.POST("/:id/move", goodController.Move)
+
+	return r
+}
+
+func TestGoodControllerV2_Create(t *testing.T) {
+	goodUsecase := mocks.NewGoodUsecase(t)
+	goodUsecase.On("Create", mock.Anything, &entity.Good{ProjectId: 2, Name: "name"}).
+		Run(func(args mock.Arguments) { args.Get(1).(*entity.Good).Id = 5 }).
+		Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/v2/projects/2/goods", strings.NewReader(`{"name":"name"}`))
+	w := httptest.NewRecorder()
+	newV2Router(goodUsecase).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/v2/projects/2/goods/5", w.Header().Get("Location"))
+}
+
+func TestGoodControllerV2_Patch(t *testing.T) {
+	newDescription, emptyDescription := "new", ""
+	tests := []struct {
+		name  string
+		body  string
+		patch *entity.GoodPatch
+		code  int
+	}{
+		{
+			name:  "change",
+			body:  `{"description":"new"}`,
+			patch: &entity.GoodPatch{Description: &newDescription},
+			code:  http.StatusOK,
+		},
+		{
+			name:  "clear description",
+			body:  `{"description":null}`,
+			patch: &entity.GoodPatch{Description: &emptyDescription},
+			code:  http.StatusOK,
+		},
+		{
+			name: "remove name",
+			body: `{"name":null}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "not object",
+			body: `[]`,
+			code: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goodUsecase := mocks.NewGoodUsecase(t)
+			if tt.patch != nil {
+				good := &entity.Good{Id: 5, ProjectId: 2, Name: "name", Description: "description"}
+				goodUsecase.On("Get", mock.Anything, 5).Return(good, nil)
+				goodUsecase.On("Patch", mock.Anything, good, *tt.patch).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPatch, "/v2/projects/2/goods/5", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			w := httptest.NewRecorder()
+			newV2Router(goodUsecase).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestGoodControllerV2_MoveOtherProject(t *testing.T) {
+	goodUsecase := mocks.NewGoodUsecase(t)
+	goodUsecase.On("Get", mock.Anything, 5).Return(&entity.Good{Id: 5, ProjectId: 3}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/v2/projects/2/goods/5/move", strings.NewReader(`{"newPriority":1}`))
+	w := httptest.NewRecorder()
+	newV2Router(goodUsecase).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error":{"code":"good_not_found","message":"good not found"}}`, w.Body.String())
+}
+
+func TestGoodControllerV2_Get(t *testing.T) {
+	goodUsecase := mocks.NewGoodUsecase(t)
+	goodUsecase.On("Get", mock.Anything, 5).Return(&entity.Good{Id: 5, ProjectId: 2, Name: "name"}, nil)
+
+	w := httptest.NewRecorder()
+	newV2Router(goodUsecase).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/projects/2/goods/5", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":5,"project_id":2,"name":"name","description":"","priority":0,"removed":false,"created_at":""}`, w.Body.String())
+}
+
+func TestGoodControllerV2_BatchGet(t *testing.T) {
+	goodUsecase := mocks.NewGoodUsecase(t)
+	goodUsecase.On("GetMany", mock.Anything, []int{3, 1, 2, 4}).
+		Return([]*entity.Good{{Id: 1, ProjectId: 2}, {Id: 2, ProjectId: 7}, {Id: 3, ProjectId: 2}}, nil)
+
+	r := newV2Router(goodUsecase)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/projects/2/goods/batch?ids=3,1,2,1,4", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"goods":[
+		{"id":3,"project_id":2,"name":"","description":"","priority":0,"removed":false,"created_at":""},
+		{"id":1,"project_id":2,"name":"","description":"","priority":0,"removed":false,"created_at":""}
+	],"missing":[2,4]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/projects/2/goods/batch?ids=1,a", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDeprecated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/good/list", Deprecated(time.Unix(1760832000, 0), "/v2/projects/{projectId}/goods"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/good/list", nil))
+
+	assert.Equal(t, "@1760832000", w.Header().Get("Deprecation"))
+	assert.Equal(t, `</v2/projects/{projectId}/goods>; rel="successor-version"`, w.Header().Get("Link"))
+}
+
+func TestGoodControllerV2_CreateInvalid(t *testing.T) {
+	goodUsecase := mocks.NewGoodUsecase(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/v2/projects/2/goods", strings.NewReader(`{"name":" ","description":"a\u0007"}`))
+	w := httptest.NewRecorder()
+	newV2Router(goodUsecase).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":{"code":"validation_failed","message":"invalid input","fields":[
+		{"field":"name","code":"required","message":"name must not be blank"},
+		{"field":"description","code":"invalid_chars","message":"description must contain printable characters and line breaks only"}
+	]}}`, w.Body.String())
+}
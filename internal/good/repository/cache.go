@@ -6,6 +6,8 @@ import (
 	"goods-manager/internal/cache"
 	"goods-manager/internal/domain"
 	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
+	"goods-manager/internal/transactor"
 	"strconv"
 )
 
@@ -16,17 +18,23 @@ type goodRepositoryCache struct {
 	goodRepository domain.GoodRepository
 }
 
-func (g *goodRepositoryCache) Create(ctx context.Context, good *entity.Good) error {
+func (g *goodRepositoryCache) Create(ctx context.Context, good *entity.Good) (err error) {
+	ctx, span := tracing.Start(ctx, "goodRepositoryCache.Create")
+	defer tracing.End(span, &err)
+
 	if err := g.goodRepository.Create(ctx, good); err != nil {
 		return err
 	}
 
-	return g.cache.Set(ctx, "good:"+strconv.Itoa(good.Id), good)
+	return g.set(ctx, good)
 }
 
-func (g *goodRepositoryCache) Get(ctx context.Context, id int) (*entity.Good, error) {
+func (g *goodRepositoryCache) Get(ctx context.Context, id int) (_ *entity.Good, err error) {
+	ctx, span := tracing.Start(ctx, "goodRepositoryCache.Get")
+	defer tracing.End(span, &err)
+
 	good := &entity.Good{}
-	err := g.cache.Get(ctx, "good:"+strconv.Itoa(id), good)
+	err = g.cache.Get(ctx, "good:"+strconv.Itoa(id), good)
 
 	if err != nil {
 		if errors.Is(err, cache.ErrorNotExists) {
@@ -36,7 +44,7 @@ func (g *goodRepositoryCache) Get(ctx context.Context, id int) (*entity.Good, er
 				return nil, err
 			}
 
-			if err := g.cache.Set(ctx, "good:"+strconv.Itoa(id), good); err != nil {
+			if err := g.set(ctx, good); err != nil {
 				return nil, err
 			}
 
@@ -48,51 +56,154 @@ func (g *goodRepositoryCache) Get(ctx context.Context, id int) (*entity.Good, er
 	return good, nil
 }
 
-func (g *goodRepositoryCache) Update(ctx context.Context, good *entity.Good) error {
+// GetMany gets goods from cache by one request and goods missing in cache from `goodRepository` by one query.
+// Goods are returned in order of `ids`, goods which don't exist are omitted.
+func (g *goodRepositoryCache) GetMany(ctx context.Context, ids []int) (_ []*entity.Good, err error) {
+	ctx, span := tracing.Start(ctx, "goodRepositoryCache.GetMany")
+	defer tracing.End(span, &err)
+
+	keys := make([]string, len(ids))
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		keys[i] = "good:" + strconv.Itoa(id)
+		values[i] = &entity.Good{}
+	}
+
+	found, err := g.cache.GetMany(ctx, keys, values)
+	if err != nil {
+		return nil, err
+	}
+
+	goods := make(map[int]*entity.Good, len(ids))
+	missing := make([]int, 0)
+	for i, id := range ids {
+		if found[i] {
+			goods[id] = values[i].(*entity.Good)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		fetched, err := g.goodRepository.GetMany(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+
+		toCache := make(map[string]interface{}, len(fetched))
+		for _, good := range fetched {
+			goods[good.Id] = good
+			cached := *good
+			toCache["good:"+strconv.Itoa(good.Id)] = &cached
+		}
+
+		err = transactor.AfterCommit(ctx, func(ctx context.Context) error {
+			return g.cache.SetMany(ctx, toCache)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]*entity.Good, 0, len(goods))
+	for _, id := range ids {
+		if good, ok := goods[id]; ok {
+			result = append(result, good)
+		}
+	}
+
+	return result, nil
+}
+
+func (g *goodRepositoryCache) Update(ctx context.Context, good *entity.Good) (err error) {
+	ctx, span := tracing.Start(ctx, "goodRepositoryCache.Update")
+	defer tracing.End(span, &err)
+
 	if err := g.goodRepository.Update(ctx, good); err != nil {
 		return err
 	}
 
-	return g.cache.Set(ctx, "good:"+strconv.Itoa(good.Id), good)
+	return g.set(ctx, good)
 }
 
-func (g *goodRepositoryCache) Delete(ctx context.Context, id int) error {
+func (g *goodRepositoryCache) UpdateFields(ctx context.Context, good *entity.Good, fields []string) (err error) {
+	ctx, span := tracing.Start(ctx, "goodRepositoryCache.UpdateFields")
+	defer tracing.End(span, &err)
+
+	if err := g.goodRepository.UpdateFields(ctx, good, fields); err != nil {
+		return err
+	}
+
+	return g.set(ctx, good)
+}
+
+func (g *goodRepositoryCache) Delete(ctx context.Context, id int) (err error) {
+	ctx, span := tracing.Start(ctx, "goodRepositoryCache.Delete")
+	defer tracing.End(span, &err)
+
 	if err := g.goodRepository.Delete(ctx, id); err != nil {
 		return err
 	}
 
-	return g.cache.Remove(ctx, "good:"+strconv.Itoa(id))
+	return g.remove(ctx, []int{id})
 }
 
-func (g *goodRepositoryCache) List(ctx context.Context, limit, offset int) ([]*entity.Good, error) {
-	return g.goodRepository.List(ctx, limit, offset)
+func (g *goodRepositoryCache) List(ctx context.Context, projectId, limit, offset int) (_ []*entity.Good, err error) {
+	ctx, span := tracing.Start(ctx, "goodRepositoryCache.List")
+	defer tracing.End(span, &err)
+
+	return g.goodRepository.List(ctx, projectId, limit, offset)
+}
+
+func (g *goodRepositoryCache) ListAfter(ctx context.Context, afterId, limit int) (_ []*entity.Good, err error) {
+	ctx, span := tracing.Start(ctx, "goodRepositoryCache.ListAfter")
+	defer tracing.End(span, &err)
+
+	return g.goodRepository.ListAfter(ctx, afterId, limit)
 }
 
-func (g *goodRepositoryCache) Reprioritize(ctx context.Context, id, newPriority int) (map[int]int, error) {
-	repositories, err := g.goodRepository.Reprioritize(ctx, id, newPriority)
+func (g *goodRepositoryCache) Reprioritize(ctx context.Context, id, newPriority int) (_ map[int]int, err error) {
+	ctx, span := tracing.Start(ctx, "goodRepositoryCache.Reprioritize")
+	defer tracing.End(span, &err)
+
+	priorities, err := g.goodRepository.Reprioritize(ctx, id, newPriority)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get good and update it priority
-	for id, priority := range repositories {
-		cacheKey := "good:" + strconv.Itoa(id)
-		var good entity.Good
-		if err := g.cache.Get(ctx, cacheKey, &good); err != nil {
-			if errors.Is(err, cache.ErrorNotExists) {
-				continue
-			}
+	// moved and shifted goods are read from repository again
+	ids := []int{id}
+	for shifted := range priorities {
+		ids = append(ids, shifted)
+	}
 
-			return nil, err
-		}
+	if err := g.remove(ctx, ids); err != nil {
+		return nil, err
+	}
 
-		good.Priority = priority
-		if err := g.cache.Set(ctx, cacheKey, good); err != nil {
-			return nil, err
+	return priorities, nil
+}
+
+// set writes copy of the good to cache after commit, so cache doesn't get changes of rolled back transaction
+func (g *goodRepositoryCache) set(ctx context.Context, good *entity.Good) error {
+	cached := *good
+	return transactor.AfterCommit(ctx, func(ctx context.Context) error {
+		return g.cache.Set(ctx, "good:"+strconv.Itoa(cached.Id), &cached)
+	})
+}
+
+// remove removes the goods from cache after commit.
+// Functions are run after commit in order of registration, so goods cached by earlier reads of the transaction are removed.
+func (g *goodRepositoryCache) remove(ctx context.Context, ids []int) error {
+	return transactor.AfterCommit(ctx, func(ctx context.Context) error {
+		for _, id := range ids {
+			if err := g.cache.Remove(ctx, "good:"+strconv.Itoa(id)); err != nil {
+				return err
+			}
 		}
-	}
 
-	return repositories, nil
+		return nil
+	})
 }
 
 func NewGoodRepositoryCache(cache cache.Cache, goodRepository domain.GoodRepository) domain.GoodRepository {
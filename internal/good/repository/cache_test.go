@@ -2,13 +2,18 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"goods-manager/internal/cache"
 	"goods-manager/internal/cache/mocks"
 	"goods-manager/internal/domain"
 	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/transactor"
 	mocks2 "goods-manager/mocks"
 	"testing"
 )
@@ -30,8 +35,8 @@ func Test_goodRepositoryCache_Create(t *testing.T) {
 	}
 	ctx := context.Background()
 
-	mockGoodRepo.On("Create", ctx, &good).Return(nil)
-	mockCache.On("Set", ctx, "good:523", &good).Return(nil)
+	mockGoodRepo.On("Create", mock.Anything, &good).Return(nil)
+	mockCache.On("Set", mock.Anything, "good:523", &good).Return(nil)
 
 	if err := cache.Create(ctx, &good); err != nil {
 		t.Fatal(err)
@@ -55,8 +60,8 @@ func Test_goodRepositoryCache_Delete(t *testing.T) {
 	}
 	ctx := context.Background()
 
-	mockGoodRepo.On("Delete", ctx, good.Id).Return(nil)
-	mockCache.On("Remove", ctx, "good:523").Return(nil)
+	mockGoodRepo.On("Delete", mock.Anything, good.Id).Return(nil)
+	mockCache.On("Remove", mock.Anything, "good:523").Return(nil)
 
 	if err := cache.Delete(ctx, good.Id); err != nil {
 		t.Fatal(err)
@@ -81,7 +86,7 @@ func Test_goodRepositoryCache_Get(t *testing.T) {
 	}
 	ctx := context.Background()
 
-	mockCache.On("Get", ctx, "good:523", &entity.Good{}).Return(nil).Run(func(args mock.Arguments) {
+	mockCache.On("Get", mock.Anything, "good:523", &entity.Good{}).Return(nil).Run(func(args mock.Arguments) {
 		mockGood := args.Get(2).(*entity.Good)
 		*mockGood = good
 	})
@@ -94,15 +99,41 @@ func Test_goodRepositoryCache_Get(t *testing.T) {
 	assert.Equal(t, &good, goodCache)
 }
 
+func Test_goodRepositoryCache_GetMany(t *testing.T) {
+	mockCache := mocks.NewCache(t)
+	mockGoodRepo := mocks2.NewGoodRepository(t)
+
+	cache := NewGoodRepositoryCache(mockCache, mockGoodRepo)
+
+	cached := entity.Good{Id: 2, ProjectId: 3, Name: "Cached"}
+	stored := &entity.Good{Id: 1, ProjectId: 3, Name: "Stored"}
+
+	mockCache.On("GetMany", mock.Anything, []string{"good:1", "good:2", "good:5"}, mock.Anything).
+		Return([]bool{false, true, false}, nil).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).([]interface{})[1].(*entity.Good) = cached
+		})
+	mockGoodRepo.On("GetMany", mock.Anything, []int{1, 5}).Return([]*entity.Good{stored}, nil)
+	mockCache.On("SetMany", mock.Anything, map[string]interface{}{"good:1": stored}).Return(nil)
+
+	goods, err := cache.GetMany(context.Background(), []int{1, 2, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []*entity.Good{stored, &cached}, goods)
+}
+
 func Test_goodRepositoryCache_List(t *testing.T) {
 	type fields struct {
 		cache          cache.Cache
 		goodRepository domain.GoodRepository
 	}
 	type args struct {
-		ctx    context.Context
-		limit  int
-		offset int
+		ctx       context.Context
+		projectId int
+		limit     int
+		offset    int
 	}
 	tests := []struct {
 		name    string
@@ -119,47 +150,104 @@ func Test_goodRepositoryCache_List(t *testing.T) {
 				cache:          tt.fields.cache,
 				goodRepository: tt.fields.goodRepository,
 			}
-			got, err := g.List(tt.args.ctx, tt.args.limit, tt.args.offset)
-			if !tt.wantErr(t, err, fmt.Sprintf("List(%v, %v, %v)", tt.args.ctx, tt.args.limit, tt.args.offset)) {
+			got, err := g.List(tt.args.ctx, tt.args.projectId, tt.args.limit, tt.args.offset)
+			if !tt.wantErr(t, err, fmt.Sprintf("List(%v, %v, %v, %v)", tt.args.ctx, tt.args.projectId, tt.args.limit, tt.args.offset)) {
 				return
 			}
-			assert.Equalf(t, tt.want, got, "List(%v, %v, %v)", tt.args.ctx, tt.args.limit, tt.args.offset)
+			assert.Equalf(t, tt.want, got, "List(%v, %v, %v, %v)", tt.args.ctx, tt.args.projectId, tt.args.limit, tt.args.offset)
 		})
 	}
 }
 
-func Test_goodRepositoryCache_Reprioritize(t *testing.T) {
-	type fields struct {
-		cache          cache.Cache
-		goodRepository domain.GoodRepository
-	}
-	type args struct {
-		ctx         context.Context
-		id          int
-		newPriority int
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    map[int]int
-		wantErr assert.ErrorAssertionFunc
-	}{
-		// TODO: Add test cases.
+// memoryCache is cache of JSON of values in memory
+type memoryCache struct {
+	cache.Cache
+	values map[string][]byte
+}
+
+func (m *memoryCache) Get(_ context.Context, key string, value interface{}) error {
+	data, ok := m.values[key]
+	if !ok {
+		return cache.ErrorNotExists
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			g := &goodRepositoryCache{
-				cache:          tt.fields.cache,
-				goodRepository: tt.fields.goodRepository,
+
+	return json.Unmarshal(data, value)
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	m.values[key] = data
+	return err
+}
+
+func (m *memoryCache) Remove(_ context.Context, key string) error {
+	delete(m.values, key)
+	return nil
+}
+
+func Test_goodRepositoryCache_Reprioritize(t *testing.T) {
+	db, sql, err := sqlmock.New()
+	require.NoError(t, err)
+	newTransactor := transactor.NewTransactor(db)
+
+	memory := &memoryCache{values: map[string][]byte{}}
+	mockGoodRepo := mocks2.NewGoodRepository(t)
+	repo := NewGoodRepositoryCache(memory, mockGoodRepo)
+
+	// good is read through cache before every move, as usecase does
+	move := func(newPriority int, moveErr error) (previous int, err error) {
+		err = newTransactor.WithTransaction(context.Background(), func(ctx context.Context) error {
+			good, err := repo.Get(ctx, 1)
+			if err != nil {
+				return err
 			}
-			got, err := g.Reprioritize(tt.args.ctx, tt.args.id, tt.args.newPriority)
-			if !tt.wantErr(t, err, fmt.Sprintf("Reprioritize(%v, %v, %v)", tt.args.ctx, tt.args.id, tt.args.newPriority)) {
-				return
+			previous = good.Priority
+
+			if _, err := repo.Reprioritize(ctx, 1, newPriority); err != nil {
+				return err
 			}
-			assert.Equalf(t, tt.want, got, "Reprioritize(%v, %v, %v)", tt.args.ctx, tt.args.id, tt.args.newPriority)
+
+			// cache doesn't see the move until commit
+			_, cached := memory.values["good:2"]
+			assert.True(t, cached)
+			return moveErr
 		})
+		return previous, err
 	}
+
+	require.NoError(t, memory.Set(context.Background(), "good:2", &entity.Good{Id: 2, Priority: 1}))
+
+	sql.ExpectBegin()
+	sql.ExpectCommit()
+	mockGoodRepo.On("Get", mock.Anything, 1).Return(&entity.Good{Id: 1, Priority: 5}, nil).Once()
+	mockGoodRepo.On("Reprioritize", mock.Anything, 1, 1).Return(map[int]int{2: 2}, nil).Once()
+	previous, err := move(1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5, previous)
+	assert.Empty(t, memory.values, "moved and shifted goods are removed after commit")
+
+	// the second move reads priority of the first one
+	sql.ExpectBegin()
+	sql.ExpectCommit()
+	mockGoodRepo.On("Get", mock.Anything, 1).Return(&entity.Good{Id: 1, Priority: 1}, nil).Once()
+	mockGoodRepo.On("Reprioritize", mock.Anything, 1, 3).Return(map[int]int{}, nil).Once()
+	memory.values["good:2"] = []byte(`{"id":2,"priority":2}`)
+	previous, err = move(3, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, previous)
+	assert.NotContains(t, memory.values, "good:1")
+
+	// rolled back move doesn't change cache
+	sql.ExpectBegin()
+	sql.ExpectRollback()
+	mockGoodRepo.On("Get", mock.Anything, 1).Return(&entity.Good{Id: 1, Priority: 3}, nil).Once()
+	mockGoodRepo.On("Reprioritize", mock.Anything, 1, 1).Return(map[int]int{2: 3}, nil).Once()
+	_, err = move(1, errors.New("publish failed"))
+	require.Error(t, err)
+	assert.Equal(t, []byte(`{"id":2,"priority":2}`), memory.values["good:2"])
+	assert.NotContains(t, memory.values, "good:1")
+
+	assert.NoError(t, sql.ExpectationsWereMet())
 }
 
 func Test_goodRepositoryCache_Update(t *testing.T) {
@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"github.com/lib/pq"
 	"goods-manager/internal/domain"
 	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
 	"goods-manager/internal/transactor"
-	"log"
+	"strings"
 )
 
 type goodRepository struct {
@@ -25,15 +28,23 @@ type goodRepository struct {
 //
 // Note:
 // - The priority is calculated by incrementing the maximum priority of existing goods. If there are no existing goods, the priority will be set to 1.
+// - `domain.ErrorProjectNotFound` is returned if the project doesn't exist or is removed.
 // - The created_at field will be automatically set to the current timestamp by the database.
-func (g *goodRepository) Create(ctx context.Context, good *entity.Good) error {
+func (g *goodRepository) Create(ctx context.Context, good *entity.Good) (err error) {
+	ctx, span := tracing.Start(ctx, "goodRepository.Create")
+	defer tracing.End(span, &err)
+
+	// good is inserted only to existing project which isn't removed,
+	// project is locked, so it isn't removed concurrently
 	query := `
-        WITH max_priority AS (
+        WITH project AS (
+            SELECT id FROM projects WHERE id = $1 AND removed = false FOR SHARE
+        ), max_priority AS (
             SELECT COALESCE(MAX(goods.priority), 0) AS priority FROM goods WHERE removed = false
         )
         
         INSERT INTO goods (project_id, name, description, priority) 
-               VALUES ($1, $2, $3, (SELECT priority + 1 FROM max_priority))
+               SELECT project.id, $2, $3, (SELECT priority + 1 FROM max_priority) FROM project
         RETURNING id, priority, removed, created_at
     `
 
@@ -45,11 +56,22 @@ func (g *goodRepository) Create(ctx context.Context, good *entity.Good) error {
 		row = db.QueryRowContext(ctx, query, good.ProjectId, good.Name, good.Description)
 	}
 
-	return row.Scan(&good.Id, &good.Priority, &good.Removed, &good.CreatedAt)
+	if err := row.Scan(&good.Id, &good.Priority, &good.Removed, &good.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrorProjectNotFound
+		}
+
+		return err
+	}
+
+	return nil
 }
 
 // Get gets a Good from the database.
-func (g *goodRepository) Get(ctx context.Context, id int) (*entity.Good, error) {
+func (g *goodRepository) Get(ctx context.Context, id int) (_ *entity.Good, err error) {
+	ctx, span := tracing.Start(ctx, "goodRepository.Get")
+	defer tracing.End(span, &err)
+
 	query := `
 		SELECT id, project_id, name, description, priority, removed, created_at FROM goods
 			WHERE id = $1
@@ -64,7 +86,7 @@ func (g *goodRepository) Get(ctx context.Context, id int) (*entity.Good, error)
 	}
 
 	var good entity.Good
-	err := row.Scan(&good.Id, &good.ProjectId, &good.Name, &good.Description, &good.Priority, &good.Removed, &good.CreatedAt)
+	err = row.Scan(&good.Id, &good.ProjectId, &good.Name, &good.Description, &good.Priority, &good.Removed, &good.CreatedAt)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -77,14 +99,59 @@ func (g *goodRepository) Get(ctx context.Context, id int) (*entity.Good, error)
 	return &good, nil
 }
 
+// GetMany gets Goods with the IDs from the database by one query.
+// Goods which don't exist are omitted, order of goods is not defined.
+func (g *goodRepository) GetMany(ctx context.Context, ids []int) (_ []*entity.Good, err error) {
+	ctx, span := tracing.Start(ctx, "goodRepository.GetMany")
+	defer tracing.End(span, &err)
+
+	query := `
+		SELECT id, project_id, name, description, priority, removed, created_at FROM goods
+			WHERE id = ANY($1)
+	`
+
+	tx, db := g.transactor.Connection(ctx)
+	var rows *sql.Rows
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, pq.Array(ids))
+	} else {
+		rows, err = db.QueryContext(ctx, query, pq.Array(ids))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeRows(rows, &err)
+
+	goods := make([]*entity.Good, 0, len(ids))
+	for rows.Next() {
+		var good entity.Good
+		err := rows.Scan(&good.Id, &good.ProjectId, &good.Name, &good.Description, &good.Priority, &good.Removed, &good.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		goods = append(goods, &good)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return goods, nil
+}
+
 // Update updates a Good in the database.
-func (g *goodRepository) Update(ctx context.Context, good *entity.Good) error {
+func (g *goodRepository) Update(ctx context.Context, good *entity.Good) (err error) {
+	ctx, span := tracing.Start(ctx, "goodRepository.Update")
+	defer tracing.End(span, &err)
+
 	query := `
 		UPDATE goods SET name = $1, description = $2 WHERE id = $3
 	`
 
 	tx, db := g.transactor.Connection(ctx)
-	var err error
 	if tx != nil {
 		_, err = tx.ExecContext(ctx, query, good.Name, good.Description, good.Id)
 	} else {
@@ -94,12 +161,63 @@ func (g *goodRepository) Update(ctx context.Context, good *entity.Good) error {
 	return err
 }
 
+// goodColumns maps names of changed fields of `entity.GoodPatch` to columns
+var goodColumns = map[string]string{
+	"name":        "name",
+	"description": "description",
+}
+
+func (g *goodRepository) UpdateFields(ctx context.Context, good *entity.Good, fields []string) (err error) {
+	ctx, span := tracing.Start(ctx, "goodRepository.UpdateFields")
+	defer tracing.End(span, &err)
+
+	values := map[string]any{"name": good.Name, "description": good.Description}
+	sets := make([]string, 0, len(fields))
+	args := make([]any, 0, len(fields)+1)
+	for _, field := range fields {
+		column, ok := goodColumns[field]
+		if !ok {
+			return fmt.Errorf("unknown field %q of good", field)
+		}
+
+		args = append(args, values[field])
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if len(sets) == 0 {
+		return errors.New("no fields of good to update")
+	}
+
+	args = append(args, good.Id)
+	query := fmt.Sprintf(`
+		UPDATE goods SET %s WHERE id = $%d
+		RETURNING id, project_id, name, COALESCE(description, ''), priority, removed, created_at
+	`, strings.Join(sets, ", "), len(args))
+
+	tx, db := g.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, args...)
+	} else {
+		row = db.QueryRowContext(ctx, query, args...)
+	}
+
+	err = row.Scan(&good.Id, &good.ProjectId, &good.Name, &good.Description, &good.Priority, &good.Removed, &good.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrorGoodNotFound
+	}
+
+	return err
+}
+
 // Delete deletes a Good from the database.
-func (g *goodRepository) Delete(ctx context.Context, id int) error {
+func (g *goodRepository) Delete(ctx context.Context, id int) (err error) {
+	ctx, span := tracing.Start(ctx, "goodRepository.Delete")
+	defer tracing.End(span, &err)
+
 	query := `UPDATE goods SET removed = true WHERE id = $1`
 
 	tx, db := g.transactor.Connection(ctx)
-	var err error
 	if tx != nil {
 		_, err = tx.ExecContext(ctx, query, id)
 	} else {
@@ -109,32 +227,75 @@ func (g *goodRepository) Delete(ctx context.Context, id int) error {
 	return err
 }
 
-// List gets a list of Goods from the database.
-func (g *goodRepository) List(ctx context.Context, limit, offset int) ([]*entity.Good, error) {
+// List gets a list of Goods of the project from the database.
+func (g *goodRepository) List(ctx context.Context, projectId, limit, offset int) (_ []*entity.Good, err error) {
+	ctx, span := tracing.Start(ctx, "goodRepository.List")
+	defer tracing.End(span, &err)
+
+	// pages of offset are stable only for total order
 	query := `
 		SELECT id, project_id, name, description, priority, removed, created_at FROM goods
-			LIMIT $1 OFFSET $2
+			WHERE project_id = $1
+			ORDER BY priority, id
+			LIMIT $2 OFFSET $3
 	`
 
 	tx, db := g.transactor.Connection(ctx)
 	var rows *sql.Rows
-	var err error
 	if tx != nil {
-		rows, err = tx.QueryContext(ctx, query, limit, offset)
+		rows, err = tx.QueryContext(ctx, query, projectId, limit, offset)
 	} else {
-		rows, err = db.QueryContext(ctx, query, limit, offset)
+		rows, err = db.QueryContext(ctx, query, projectId, limit, offset)
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	defer func(rows *sql.Rows) {
-		err := rows.Close()
+	defer closeRows(rows, &err)
+
+	goods := make([]*entity.Good, 0)
+	for rows.Next() {
+		var good entity.Good
+		err := rows.Scan(&good.Id, &good.ProjectId, &good.Name, &good.Description, &good.Priority, &good.Removed, &good.CreatedAt)
 		if err != nil {
-			log.Panicln("failed closed rows", err)
+			return nil, err
 		}
-	}(rows)
+
+		goods = append(goods, &good)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return goods, nil
+}
+
+func (g *goodRepository) ListAfter(ctx context.Context, afterId, limit int) (_ []*entity.Good, err error) {
+	ctx, span := tracing.Start(ctx, "goodRepository.ListAfter")
+	defer tracing.End(span, &err)
+
+	query := `
+		SELECT id, project_id, name, COALESCE(description, ''), priority, removed, created_at FROM goods
+			WHERE id > $1
+			ORDER BY id
+			LIMIT $2
+	`
+
+	tx, db := g.transactor.Connection(ctx)
+	var rows *sql.Rows
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, afterId, limit)
+	} else {
+		rows, err = db.QueryContext(ctx, query, afterId, limit)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeRows(rows, &err)
 
 	goods := make([]*entity.Good, 0)
 	for rows.Next() {
@@ -147,19 +308,27 @@ func (g *goodRepository) List(ctx context.Context, limit, offset int) ([]*entity
 		goods = append(goods, &good)
 	}
 
-	return goods, err
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return goods, nil
 }
 
-func (g *goodRepository) Reprioritize(ctx context.Context, id, newPriority int) (map[int]int, error) {
+func (g *goodRepository) Reprioritize(ctx context.Context, id, newPriority int) (_ map[int]int, err error) {
+	ctx, span := tracing.Start(ctx, "goodRepository.Reprioritize")
+	defer tracing.End(span, &err)
+
+	// only goods of the project of the moved good are shifted
 	queryUpdateAfter := `
 		UPDATE goods SET priority = priority + 1 
 		             WHERE priority >= $1 and id != $2
+		               AND project_id = (SELECT project_id FROM goods WHERE id = $2)
 		             RETURNING id, priority;
 	`
 
 	tx, db := g.transactor.Connection(ctx)
 	var rows *sql.Rows
-	var err error
 
 	if tx != nil {
 		rows, err = tx.QueryContext(ctx, queryUpdateAfter, newPriority, id)
@@ -171,12 +340,7 @@ func (g *goodRepository) Reprioritize(ctx context.Context, id, newPriority int)
 		return nil, err
 	}
 
-	defer func(rows *sql.Rows) {
-		err := rows.Close()
-		if err != nil {
-			log.Panicln("failed closed rows", err)
-		}
-	}(rows)
+	defer closeRows(rows, &err)
 
 	priorities := make(map[int]int)
 	for rows.Next() {
@@ -190,6 +354,10 @@ func (g *goodRepository) Reprioritize(ctx context.Context, id, newPriority int)
 		priorities[id] = priority
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
 	queryUpdateGood := `UPDATE goods SET priority = $1 WHERE id = $2;`
 
 	if tx != nil {
@@ -205,6 +373,13 @@ func (g *goodRepository) Reprioritize(ctx context.Context, id, newPriority int)
 	return priorities, nil
 }
 
+// closeRows closes rows and joins close error with error pointed by `err`
+func closeRows(rows *sql.Rows, err *error) {
+	if closeErr := rows.Close(); closeErr != nil {
+		*err = errors.Join(*err, fmt.Errorf("failed close rows: %w", closeErr))
+	}
+}
+
 func NewGoodRepository(transactor *transactor.Transactor) domain.GoodRepository {
 	return &goodRepository{transactor: transactor}
 }
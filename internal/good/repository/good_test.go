@@ -2,10 +2,15 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/domain"
 	"goods-manager/internal/domain/entity"
 	"goods-manager/internal/transactor"
+	"regexp"
 	"testing"
 )
 
@@ -56,6 +61,22 @@ func Test_goodRepository_Create(t *testing.T) {
 	assert.Equal(t, oldGood.Description, good.Description)
 }
 
+func Test_goodRepository_Create_removedProject(t *testing.T) {
+	repo, mock, err := initTestRepository()
+	require.NoError(t, err)
+
+	good := entity.Good{ProjectId: 4, Name: "Good 1"}
+
+	// project which is removed or doesn't exist selects no rows to insert
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM projects WHERE id = $1 AND removed = false FOR SHARE")).
+		WithArgs(good.ProjectId, good.Name, good.Description).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "priority", "removed", "created_at"}))
+
+	err = repo.Create(context.Background(), &good)
+	assert.ErrorIs(t, err, domain.ErrorProjectNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func Test_goodRepository_Get(t *testing.T) {
 	repo, mock, err := initTestRepository()
 	if err != nil {
@@ -112,6 +133,32 @@ func Test_goodRepository_Update(t *testing.T) {
 	}
 }
 
+func Test_goodRepository_UpdateFields(t *testing.T) {
+	repo, mock, err := initTestRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// name is stale, it is changed concurrently
+	good := &entity.Good{Id: 2, ProjectId: 4, Name: "stale", Description: "new"}
+
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE goods SET description = $1 WHERE id = $2")).
+		WithArgs("new", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "name", "description", "priority", "removed", "created_at"}).
+			AddRow(2, 4, "fresh", "new", 3, false, "2024-03-05 12:00:00"))
+
+	if err := repo.UpdateFields(context.Background(), good, []string{"description"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, &entity.Good{Id: 2, ProjectId: 4, Name: "fresh", Description: "new", Priority: 3, CreatedAt: "2024-03-05 12:00:00"}, good)
+	assert.Error(t, repo.UpdateFields(context.Background(), good, []string{"priority"}))
+}
+
 func Test_goodRepository_Delete(t *testing.T) {
 	repo, mock, err := initTestRepository()
 	if err != nil {
@@ -140,16 +187,17 @@ func Test_goodRepository_List(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	projectId := 1
 	limit := 10
 	offset := 0
 
-	mock.ExpectQuery("SELECT id, project_id, name, description, priority, removed, created_at FROM goods LIMIT ?").
-		WithArgs(limit, offset).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, project_id, name, description, priority, removed, created_at FROM goods WHERE project_id = $1 ORDER BY priority, id LIMIT $2 OFFSET $3")).
+		WithArgs(projectId, limit, offset).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "name", "description", "priority", "removed", "created_at"}).
 			AddRow(1, 1, "name_1", "description_1", 1, false, "2024-03-05 12:00:00").
-			AddRow(2, 2, "name_2", "description_2", 2, false, "2024-03-06 12:00:00"))
+			AddRow(2, 1, "name_2", "description_2", 2, false, "2024-03-06 12:00:00"))
 
-	goods, err := repo.List(context.Background(), limit, offset)
+	goods, err := repo.List(context.Background(), projectId, limit, offset)
 
 	if err != nil {
 		t.Errorf("Error listing goods: %v", err)
@@ -163,7 +211,7 @@ func Test_goodRepository_List(t *testing.T) {
 		t.Errorf("Unexpected content for the first good")
 	}
 
-	if goods[1].Id != 2 || goods[1].ProjectId != 2 || goods[1].Name != "name_2" {
+	if goods[1].Id != 2 || goods[1].ProjectId != 1 || goods[1].Name != "name_2" {
 		t.Errorf("Unexpected content for the second good")
 	}
 
@@ -172,6 +220,24 @@ func Test_goodRepository_List(t *testing.T) {
 	}
 }
 
+func Test_goodRepository_List_CloseError(t *testing.T) {
+	repo, mock, err := initTestRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closeErr := errors.New("connection lost")
+	mock.ExpectQuery("SELECT id, project_id, name, description, priority, removed, created_at FROM goods WHERE project_id = ?").
+		WithArgs(1, 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "name", "description", "priority", "removed", "created_at"}).
+			AddRow(1, 1, "name_1", "description_1", 1, false, "2024-03-05 12:00:00").
+			CloseError(closeErr))
+
+	_, err = repo.List(context.Background(), 1, 10, 0)
+
+	assert.ErrorIs(t, err, closeErr)
+}
+
 func Test_goodRepository_Reprioritize(t *testing.T) {
 	repo, mock, err := initTestRepository()
 	if err != nil {
@@ -182,7 +248,7 @@ func Test_goodRepository_Reprioritize(t *testing.T) {
 	newPriority := 5
 
 	// Mock expected SQL query and its result for updating priorities
-	mock.ExpectQuery("UPDATE goods").
+	mock.ExpectQuery(regexp.QuoteMeta("AND project_id = (SELECT project_id FROM goods WHERE id = $2)")).
 		WithArgs(newPriority, id).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "priority"}).
 			AddRow(2, 6).
@@ -218,3 +284,31 @@ func Test_goodRepository_Reprioritize(t *testing.T) {
 		t.Errorf("There were unfulfilled expectations: %s", err)
 	}
 }
+
+func Test_goodRepository_GetMany(t *testing.T) {
+	repo, mock, err := initTestRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createdAt := "2024-03-05 12:00:00"
+	mock.ExpectQuery("SELECT id, project_id, name, description, priority, removed, created_at FROM goods WHERE id = ANY").
+		WithArgs(pq.Array([]int{1, 2, 3})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "name", "description", "priority", "removed", "created_at"}).
+			AddRow(1, 4, "Good 1", "", 1, false, createdAt).
+			AddRow(3, 4, "Good 3", "", 2, false, createdAt))
+
+	goods, err := repo.GetMany(context.Background(), []int{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, []*entity.Good{
+		{Id: 1, ProjectId: 4, Name: "Good 1", Priority: 1, CreatedAt: createdAt},
+		{Id: 3, ProjectId: 4, Name: "Good 3", Priority: 2, CreatedAt: createdAt},
+	}, goods)
+}
@@ -1,104 +1,290 @@
 package usecase
 
 import (
+	"cmp"
 	"context"
-	"errors"
 	"goods-manager/internal/domain"
 	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
 	"goods-manager/internal/transactor"
+	"log/slog"
+	"slices"
 )
 
 // goodUsecase implementation `domain.GoodUsecase`.
 //
 // Mutable operation wrapper with transaction
-// Some operation send log to queue.
+// Some operation send log to queue and publish webhook events in the transaction.
 type goodUsecase struct {
-	goodRepo      domain.GoodRepository
-	loggerUsecase domain.LoggerUsecase
-	transactor    *transactor.Transactor
+	goodRepo       domain.GoodRepository
+	loggerUsecase  domain.LoggerUsecase
+	webhookUsecase domain.WebhookUsecase
+	transactor     *transactor.Transactor
+	logger         *slog.Logger
 }
 
 // Create new good and send log
-func (g *goodUsecase) Create(ctx context.Context, good *entity.Good) error {
-	if good.Name == "" || good.ProjectId == 0 {
-		return errors.New("invalid data")
+func (g *goodUsecase) Create(ctx context.Context, good *entity.Good) (err error) {
+	ctx, span := tracing.Start(ctx, "goodUsecase.Create")
+	defer tracing.End(span, &err)
+
+	if err := validateGood(good, false); err != nil {
+		return err
 	}
 
-	return g.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+	err = g.transactor.WithTransaction(ctx, func(ctx context.Context) error {
 		err := g.goodRepo.Create(ctx, good)
 
 		if err == nil {
-			if err := g.loggerUsecase.SendToQueue(ctx, good); err != nil {
+			if err := g.loggerUsecase.SendToQueue(ctx, entity.EventGoodCreated, good, nil); err != nil {
 				return err
 			}
+
+			return g.webhookUsecase.Publish(ctx, good.ProjectId, entity.EventGoodCreated, good)
 		}
 
 		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	g.logger.InfoContext(ctx, "good created", slog.Int("id", good.Id), slog.Int("project_id", good.ProjectId))
+	return nil
 }
 
-func (g *goodUsecase) Get(ctx context.Context, id int) (*entity.Good, error) {
+func (g *goodUsecase) Get(ctx context.Context, id int) (_ *entity.Good, err error) {
+	ctx, span := tracing.Start(ctx, "goodUsecase.Get")
+	defer tracing.End(span, &err)
+
 	return g.goodRepo.Get(ctx, id)
 }
 
+func (g *goodUsecase) GetMany(ctx context.Context, ids []int) (_ []*entity.Good, err error) {
+	ctx, span := tracing.Start(ctx, "goodUsecase.GetMany")
+	defer tracing.End(span, &err)
+
+	return g.goodRepo.GetMany(ctx, ids)
+}
+
 // Update good and send log
-func (g *goodUsecase) Update(ctx context.Context, good *entity.Good) error {
-	if good.Id == 0 || good.Name == "" || good.ProjectId == 0 {
-		return errors.New("invalid data")
+func (g *goodUsecase) Update(ctx context.Context, good *entity.Good) (err error) {
+	ctx, span := tracing.Start(ctx, "goodUsecase.Update")
+	defer tracing.End(span, &err)
+
+	if err := validateGood(good, true); err != nil {
+		return err
 	}
 
-	return g.transactor.WithTransaction(ctx, func(ctx context.Context) error {
-		err := g.goodRepo.Update(ctx, good)
+	err = g.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		previous, err := g.goodRepo.Get(ctx, good.Id)
+		if err != nil {
+			return err
+		}
 
-		if err == nil {
-			if err := g.loggerUsecase.SendToQueue(ctx, good); err != nil {
-				return err
-			}
+		if err := g.goodRepo.Update(ctx, good); err != nil {
+			return err
+		}
+
+		if err := g.loggerUsecase.SendToQueue(ctx, entity.EventGoodUpdated, good, previous); err != nil {
+			return err
 		}
 
+		return g.webhookUsecase.Publish(ctx, good.ProjectId, entity.EventGoodUpdated, good)
+	})
+	if err != nil {
+		return err
+	}
+
+	g.logger.InfoContext(ctx, "good updated", slog.Int("id", good.Id), slog.Int("project_id", good.ProjectId))
+	return nil
+}
+
+// Patch applies changed fields to the good, saves only them and sends log.
+// Other fields of the good are refreshed from the saved row, so concurrent patches of different fields don't overwrite each other.
+func (g *goodUsecase) Patch(ctx context.Context, good *entity.Good, patch entity.GoodPatch) (err error) {
+	ctx, span := tracing.Start(ctx, "goodUsecase.Patch")
+	defer tracing.End(span, &err)
+
+	patched := *good
+	changed := patch.Apply(&patched)
+	if len(changed) == 0 {
+		g.logger.DebugContext(ctx, "good not changed", slog.Int("id", good.Id), slog.Int("project_id", good.ProjectId))
+		return nil
+	}
+
+	if err := validateGood(&patched, true); err != nil {
 		return err
+	}
+
+	err = g.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := g.goodRepo.UpdateFields(ctx, &patched, changed); err != nil {
+			return err
+		}
+
+		if err := g.loggerUsecase.SendToQueue(ctx, entity.EventGoodUpdated, &patched, good); err != nil {
+			return err
+		}
+
+		return g.webhookUsecase.Publish(ctx, patched.ProjectId, entity.EventGoodUpdated, &patched)
 	})
+	if err != nil {
+		return err
+	}
+
+	*good = patched
+	g.logger.InfoContext(ctx, "good patched", slog.Int("id", good.Id), slog.Int("project_id", good.ProjectId), slog.Any("changed", changed))
+	return nil
 }
 
 // Delete good and send log
-func (g *goodUsecase) Delete(ctx context.Context, good *entity.Good) error {
-	if good.Id == 0 || good.Name == "" || good.ProjectId == 0 {
-		return errors.New("invalid data")
+func (g *goodUsecase) Delete(ctx context.Context, good *entity.Good) (err error) {
+	ctx, span := tracing.Start(ctx, "goodUsecase.Delete")
+	defer tracing.End(span, &err)
+
+	if err := validateGood(good, true); err != nil {
+		return err
 	}
 
-	return g.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+	err = g.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		previous := *good
 		good.Removed = true
 		err := g.goodRepo.Delete(ctx, good.Id)
 
 		if err == nil {
-			if err := g.loggerUsecase.SendToQueue(ctx, good); err != nil {
+			if err := g.loggerUsecase.SendToQueue(ctx, entity.EventGoodDeleted, good, &previous); err != nil {
 				return err
 			}
+
+			return g.webhookUsecase.Publish(ctx, good.ProjectId, entity.EventGoodDeleted, good)
 		}
 
 		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	g.logger.InfoContext(ctx, "good deleted", slog.Int("id", good.Id), slog.Int("project_id", good.ProjectId))
+	return nil
 }
 
-func (g *goodUsecase) List(ctx context.Context, limit, offset int) ([]*entity.Good, error) {
-	return g.goodRepo.List(ctx, limit, offset)
+func (g *goodUsecase) List(ctx context.Context, projectId, limit, offset int) (_ []*entity.Good, err error) {
+	ctx, span := tracing.Start(ctx, "goodUsecase.List")
+	defer tracing.End(span, &err)
+
+	return g.goodRepo.List(ctx, projectId, limit, offset)
 }
 
-func (g *goodUsecase) Reprioritize(ctx context.Context, id, newPriority int) (map[int]int, error) {
-	if id < 1 || newPriority < 1 {
-		return nil, errors.New("invalid data")
+func (g *goodUsecase) Reprioritize(ctx context.Context, id, newPriority int) (_ map[int]int, err error) {
+	ctx, span := tracing.Start(ctx, "goodUsecase.Reprioritize")
+	defer tracing.End(span, &err)
+
+	var fields []domain.FieldError
+	if id < 1 {
+		fields = append(fields, domain.FieldError{Field: "id", Code: domain.FieldInvalid, Message: "id must be greater than 0"})
+	}
+	if newPriority < 1 {
+		fields = append(fields, domain.FieldError{Field: "newPriority", Code: domain.FieldInvalid, Message: "newPriority must be greater than 0"})
+	}
+	if len(fields) > 0 {
+		return nil, domain.NewValidationError("invalid priority", fields...)
 	}
 
 	var priorities map[int]int
-	err := g.transactor.WithTransaction(ctx, func(ctx context.Context) error {
-		newPriorities, err := g.goodRepo.Reprioritize(ctx, id, newPriority)
-		priorities = newPriorities
-		return err
+	err = g.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		good, err := g.goodRepo.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		shifted, err := g.goodRepo.Reprioritize(ctx, id, newPriority)
+		if err != nil {
+			return err
+		}
+
+		previous := *good
+		good.Priority = newPriority
+		if err := g.loggerUsecase.SendToQueue(ctx, entity.EventGoodReprioritized, good, &previous); err != nil {
+			return err
+		}
+
+		priorities, err = g.logPriorityChanges(ctx, good.ProjectId, shifted)
+		if err != nil {
+			return err
+		}
+
+		return g.webhookUsecase.Publish(ctx, good.ProjectId, entity.EventGoodReprioritized, entity.Reprioritization{Good: good, Priorities: priorities})
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	g.logger.InfoContext(ctx, "good reprioritized", slog.Int("id", id), slog.Int("priority", newPriority), slog.Int("affected", len(priorities)))
+	return priorities, nil
+}
+
+// logPriorityChanges sends events of goods of the project shifted by reprioritization in order of their new priorities
+// and returns their priorities. Goods of other projects are skipped, so events and webhooks don't leak them.
+// Shifted goods are incremented by one, so previous priority is one less.
+func (g *goodUsecase) logPriorityChanges(ctx context.Context, projectId int, priorities map[int]int) (map[int]int, error) {
+	ids := make([]int, 0, len(priorities))
+	for id := range priorities {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b int) int {
+		if priorities[a] != priorities[b] {
+			return cmp.Compare(priorities[a], priorities[b])
+		}
+
+		return cmp.Compare(a, b)
+	})
+
+	goods, err := g.goodRepo.GetMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	projectPriorities := make(map[int]int, len(goods))
+	for _, good := range goods {
+		if good.ProjectId != projectId {
+			g.logger.ErrorContext(ctx, "good of another project is shifted", slog.Int("id", good.Id), slog.Int("project_id", good.ProjectId))
+			continue
+		}
+
+		good.Priority = priorities[good.Id]
+		projectPriorities[good.Id] = good.Priority
+		previous := *good
+		previous.Priority--
+
+		if err := g.loggerUsecase.SendToQueue(ctx, entity.EventGoodPriorityChanged, good, &previous); err != nil {
+			return nil, err
+		}
+	}
+
+	return projectPriorities, nil
+}
+
+// validateGood checks required fields of the good, id is checked if `withId` is true
+func validateGood(good *entity.Good, withId bool) error {
+	var fields []domain.FieldError
+	if withId && good.Id < 1 {
+		fields = append(fields, domain.FieldError{Field: "id", Code: domain.FieldInvalid, Message: "id must be greater than 0"})
+	}
+	if good.ProjectId < 1 {
+		fields = append(fields, domain.FieldError{Field: "projectId", Code: domain.FieldInvalid, Message: "projectId must be greater than 0"})
+	}
+	if good.Name == "" {
+		fields = append(fields, domain.FieldError{Field: "name", Code: domain.FieldRequired, Message: "name is required"})
+	}
+
+	if len(fields) > 0 {
+		return domain.NewValidationError("invalid good", fields...)
+	}
 
-	return priorities, err
+	return nil
 }
 
-func NewGoodUsecase(goodRepo domain.GoodRepository, loggerUsecase domain.LoggerUsecase, transactor *transactor.Transactor) domain.GoodUsecase {
-	return &goodUsecase{goodRepo: goodRepo, loggerUsecase: loggerUsecase, transactor: transactor}
+func NewGoodUsecase(goodRepo domain.GoodRepository, loggerUsecase domain.LoggerUsecase, webhookUsecase domain.WebhookUsecase, transactor *transactor.Transactor, logger *slog.Logger) domain.GoodUsecase {
+	return &goodUsecase{goodRepo: goodRepo, loggerUsecase: loggerUsecase, webhookUsecase: webhookUsecase, transactor: transactor, logger: logger}
 }
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/transactor"
+	"goods-manager/mocks"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func Test_goodUsecase_Reprioritize(t *testing.T) {
+	db, sql, err := sqlmock.New()
+	require.NoError(t, err)
+	sql.ExpectBegin()
+	sql.ExpectCommit()
+
+	goodRepo := mocks.NewGoodRepository(t)
+	loggerUsecase := mocks.NewLoggerUsecase(t)
+	webhookUsecase := mocks.NewWebhookUsecase(t)
+
+	priorities := map[int]int{3: 4, 2: 3}
+	goodRepo.On("Get", mock.Anything, 1).Return(&entity.Good{Id: 1, ProjectId: 1, Name: "moved", Priority: 5}, nil)
+	goodRepo.On("Reprioritize", mock.Anything, 1, 3).Return(priorities, nil)
+	goodRepo.On("GetMany", mock.Anything, []int{2, 3}).Return([]*entity.Good{
+		{Id: 2, ProjectId: 1, Name: "second", Priority: 3},
+		{Id: 3, ProjectId: 2, Name: "third", Priority: 4},
+	}, nil)
+
+	type event struct {
+		eventType          entity.EventType
+		id                 int
+		priority, previous int
+	}
+	var events []event
+	loggerUsecase.On("SendToQueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			good, previous := args.Get(2).(*entity.Good), args.Get(3).(*entity.Good)
+			events = append(events, event{args.Get(1).(entity.EventType), good.Id, good.Priority, previous.Priority})
+		}).
+		Return(nil)
+	webhookUsecase.On("Publish", mock.Anything, 1, entity.EventGoodReprioritized, mock.MatchedBy(func(payload entity.Reprioritization) bool {
+		_, leaked := payload.Priorities[3]
+		return !leaked
+	})).Return(nil)
+
+	usecase := NewGoodUsecase(goodRepo, loggerUsecase, webhookUsecase, transactor.NewTransactor(db), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	got, err := usecase.Reprioritize(context.Background(), 1, 3)
+	require.NoError(t, err)
+	// good of another project is neither returned nor published
+	assert.Equal(t, map[int]int{2: 3}, got)
+	assert.NoError(t, sql.ExpectationsWereMet())
+
+	// moved good first, then shifted goods of the project in order of priorities
+	assert.Equal(t, []event{
+		{entity.EventGoodReprioritized, 1, 3, 5},
+		{entity.EventGoodPriorityChanged, 2, 3, 2},
+	}, events)
+}
+
+func Test_goodUsecase_Patch(t *testing.T) {
+	db, sql, err := sqlmock.New()
+	require.NoError(t, err)
+	sql.ExpectBegin()
+	sql.ExpectCommit()
+
+	goodRepo := mocks.NewGoodRepository(t)
+	loggerUsecase := mocks.NewLoggerUsecase(t)
+	webhookUsecase := mocks.NewWebhookUsecase(t)
+
+	// only description is written, name changed by another request is kept
+	goodRepo.On("UpdateFields", mock.Anything, mock.Anything, []string{"description"}).
+		Run(func(args mock.Arguments) { args.Get(1).(*entity.Good).Name = "concurrent" }).
+		Return(nil)
+	loggerUsecase.On("SendToQueue", mock.Anything, entity.EventGoodUpdated, mock.Anything, mock.Anything).Return(nil)
+	webhookUsecase.On("Publish", mock.Anything, 1, entity.EventGoodUpdated, mock.Anything).Return(nil)
+
+	description := "new"
+	good := &entity.Good{Id: 5, ProjectId: 1, Name: "old", Description: "old"}
+	usecase := NewGoodUsecase(goodRepo, loggerUsecase, webhookUsecase, transactor.NewTransactor(db), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, usecase.Patch(context.Background(), good, entity.GoodPatch{Description: &description}))
+	assert.NoError(t, sql.ExpectationsWereMet())
+
+	goodRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	assert.Equal(t, &entity.Good{Id: 5, ProjectId: 1, Name: "concurrent", Description: "new"}, good)
+}
@@ -0,0 +1,118 @@
+package grpcerror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/logging"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"log/slog"
+)
+
+// ErrorDomain is domain of `ErrorInfo` details of errors
+const ErrorDomain = "goods-manager"
+
+// Code returns gRPC code of the error, errors which are not domain errors are internal
+func Code(err error) codes.Code {
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) {
+		return codes.Internal
+	}
+
+	switch domainErr.Kind {
+	case domain.KindValidation:
+		return codes.InvalidArgument
+	case domain.KindUnauthorized:
+		return codes.Unauthenticated
+	case domain.KindForbidden:
+		return codes.PermissionDenied
+	case domain.KindNotFound:
+		return codes.NotFound
+	case domain.KindConflict:
+		return codes.FailedPrecondition
+	case domain.KindRateLimited:
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
+
+// Status converts error to gRPC status.
+//
+// Code of domain error is sent in `ErrorInfo` details and invalid fields in `BadRequest` details,
+// so clients get the same information as from HTTP error envelope.
+// Internal errors are hidden, they are logged.
+func Status(ctx context.Context, err error, logger *slog.Logger) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	code := Code(err)
+	var domainErr *domain.Error
+	if code == codes.Internal || !errors.As(err, &domainErr) {
+		logger.ErrorContext(ctx, "request failed", slog.Any("error", err))
+		domainErr = &domain.Error{Code: domain.CodeInternal, Message: "internal server error"}
+	}
+
+	info := &errdetails.ErrorInfo{Reason: domainErr.Code, Domain: ErrorDomain}
+	if requestId := logging.RequestIDFromContext(ctx); requestId != "" {
+		info.Metadata = map[string]string{"request_id": requestId}
+	}
+
+	details := []protoiface.MessageV1{info}
+	if len(domainErr.Fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range domainErr.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	st := status.New(code, domainErr.Message)
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+
+	return withDetails
+}
+
+// UnaryServerInterceptor converts errors of handlers to gRPC statuses and recovers panics
+func UnaryServerInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+			if err != nil {
+				err = Status(ctx, err, logger).Err()
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor converts errors of stream handlers to gRPC statuses and recovers panics
+func StreamServerInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+			if err != nil {
+				err = Status(stream.Context(), err, logger).Err()
+			}
+		}()
+
+		return handler(srv, stream)
+	}
+}
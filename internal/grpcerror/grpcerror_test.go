@@ -0,0 +1,40 @@
+package grpcerror
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"goods-manager/internal/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+		reason  string
+	}{
+		{name: "validation", err: domain.NewFieldError("name", domain.FieldRequired, "name is required"), code: codes.InvalidArgument, message: "invalid input", reason: domain.CodeValidation},
+		{name: "not found", err: domain.ErrorGoodNotFound, code: codes.NotFound, message: "good not found", reason: domain.CodeGoodNotFound},
+		{name: "forbidden", err: domain.NewForbiddenError(domain.CodeRoleRequired, "role admin is required"), code: codes.PermissionDenied, message: "role admin is required", reason: domain.CodeRoleRequired},
+		{name: "internal is hidden", err: errors.New("connection refused"), code: codes.Internal, message: "internal server error", reason: domain.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := Status(context.Background(), tt.err, logger)
+
+			assert.Equal(t, tt.code, st.Code())
+			assert.Equal(t, tt.message, st.Message())
+			assert.Equal(t, tt.reason, st.Details()[0].(*errdetails.ErrorInfo).Reason)
+		})
+	}
+}
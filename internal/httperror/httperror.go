@@ -0,0 +1,89 @@
+package httperror
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/logging"
+	"log/slog"
+	"net/http"
+)
+
+// Response is JSON envelope of every error response
+type Response struct {
+	Error Body `json:"error"`
+}
+
+// Body describes error of the request
+type Body struct {
+	// Code is stable code of the error, e.g. `good_not_found`
+	Code string `json:"code" example:"validation_failed"`
+
+	// Message is human-readable description, it may change
+	Message string `json:"message" example:"invalid input"`
+
+	// Fields are details of validation error
+	Fields []domain.FieldError `json:"fields,omitempty"`
+
+	RequestId string `json:"request_id,omitempty"`
+}
+
+// Abort stops handling of the request with `err`, which is written by `Middleware`
+func Abort(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// Status returns HTTP status of the error
+func Status(err error) int {
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) {
+		return http.StatusInternalServerError
+	}
+
+	switch domainErr.Kind {
+	case domain.KindValidation:
+		return http.StatusBadRequest
+	case domain.KindUnauthorized:
+		return http.StatusUnauthorized
+	case domain.KindForbidden:
+		return http.StatusForbidden
+	case domain.KindNotFound:
+		return http.StatusNotFound
+	case domain.KindConflict:
+		return http.StatusConflict
+	case domain.KindRateLimited:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Middleware writes last error of the request as `Response` if response is not written yet.
+// Handlers abort requests with errors by `Abort`.
+//
+// Domain errors are mapped to statuses by kind, other errors are 500 and their messages are not exposed.
+func Middleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		// response is already written or its status is set by handler
+		if len(c.Errors) == 0 || c.Writer.Written() || c.Writer.Status() != http.StatusOK {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		status := Status(err)
+		body := Body{Code: domain.CodeInternal, Message: "internal server error"}
+
+		var domainErr *domain.Error
+		if status < http.StatusInternalServerError && errors.As(err, &domainErr) {
+			body = Body{Code: domainErr.Code, Message: domainErr.Message, Fields: domainErr.Fields}
+		} else {
+			logger.ErrorContext(c.Request.Context(), "request failed", slog.Any("error", err))
+		}
+
+		body.RequestId = logging.RequestIDFromContext(c.Request.Context())
+		c.AbortWithStatusJSON(status, Response{Error: body})
+	}
+}
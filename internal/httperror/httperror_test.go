@@ -0,0 +1,96 @@
+package httperror
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/logging"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	r := gin.New()
+	r.Use(logging.RequestID())
+	r.Use(Middleware(logger))
+	r.Use(logging.Recovery(logger))
+
+	r.GET("/validation", func(c *gin.Context) {
+		Abort(c, domain.NewValidationError("invalid good",
+			domain.FieldError{Field: "name", Code: domain.FieldRequired, Message: "name is required"}))
+	})
+	r.GET("/not-found", func(c *gin.Context) {
+		Abort(c, fmt.Errorf("failed get good: %w", domain.ErrorGoodNotFound))
+	})
+	r.GET("/internal", func(c *gin.Context) {
+		Abort(c, errors.New("connection refused"))
+	})
+	r.GET("/panic", func(c *gin.Context) {
+		panic("unexpected")
+	})
+	r.GET("/ok", func(c *gin.Context) {
+		_ = c.Error(errors.New("ignored"))
+		c.Status(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{
+			path:   "/validation",
+			status: http.StatusBadRequest,
+			body:   `{"error":{"code":"validation_failed","message":"invalid good","fields":[{"field":"name","code":"required","message":"name is required"}],"request_id":"req-1"}}`,
+		},
+		{
+			path:   "/not-found",
+			status: http.StatusNotFound,
+			body:   `{"error":{"code":"good_not_found","message":"good not found","request_id":"req-1"}}`,
+		},
+		{
+			path:   "/internal",
+			status: http.StatusInternalServerError,
+			body:   `{"error":{"code":"internal","message":"internal server error","request_id":"req-1"}}`,
+		},
+		{
+			path:   "/panic",
+			status: http.StatusInternalServerError,
+			body:   `{"error":{"code":"internal","message":"internal server error","request_id":"req-1"}}`,
+		},
+		{
+			path:   "/ok",
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(logging.RequestIDHeader, "req-1")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.body != "" {
+				assert.JSONEq(t, tt.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	err := fmt.Errorf("failed: %w", domain.NewNotFoundError(domain.CodeGoodNotFound, "good 5 not found"))
+
+	assert.ErrorIs(t, err, domain.ErrorGoodNotFound)
+	assert.NotErrorIs(t, err, domain.ErrorAPIKeyNotFound)
+	assert.Equal(t, http.StatusNotFound, Status(err))
+}
@@ -0,0 +1,195 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"goods-manager/internal/cache"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/httperror"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	// Header is header of idempotency key
+	Header = "Idempotency-Key"
+
+	// ReplayedHeader is set to responses replayed from the store
+	ReplayedHeader = "Idempotent-Replayed"
+
+	// maxKeyLength is max length of idempotency key
+	maxKeyLength = 255
+
+	// lockTTL is time while key is reserved by request in progress,
+	// so key of crashed request is released eventually
+	lockTTL = 1 * time.Minute
+)
+
+var errorInProgress = domain.NewConflictError(domain.CodeIdempotencyKeyInProgress, "request with the "+Header+" is in progress")
+
+// record is state of idempotency key stored in cache
+type record struct {
+	// Hash is hash of the request which reserved the key
+	Hash string `json:"hash"`
+
+	// Completed is false while request is in progress
+	Completed bool `json:"completed"`
+
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// Middleware makes request idempotent if it has `Idempotency-Key` header.
+//
+// First response of the key is stored for `window` and replayed for retries of the same request.
+// Reuse of the key with another request gets 409, as well as retry while first request is in progress.
+// Error responses written by error middleware and server errors are not stored, so such requests may be retried.
+//
+// Keys are scoped by authenticated principal.
+func Middleware(store cache.Cache, window time.Duration, logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(Header)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		if !validKey(key) {
+			httperror.Abort(c, domain.NewFieldError(Header, domain.FieldInvalid, Header+" must be printable ASCII string up to 255 characters"))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			httperror.Abort(c, domain.NewValidationError("failed read body").Wrap(err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		storeKey := "idempotency:" + scope(c) + ":" + key
+		hash := requestHash(c, body)
+
+		reserved, err := store.SetNX(ctx, storeKey, record{Hash: hash}, lockTTL)
+		if err != nil {
+			httperror.Abort(c, fmt.Errorf("failed reserve idempotency key: %w", err))
+			return
+		}
+
+		if !reserved {
+			replay(c, store, storeKey, hash)
+			return
+		}
+
+		writer := &bodyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		// error is written later by error middleware, so response is not known
+		if (len(c.Errors) > 0 && !c.Writer.Written()) || c.Writer.Status() >= http.StatusInternalServerError {
+			if err := store.Remove(ctx, storeKey); err != nil {
+				logger.ErrorContext(ctx, "failed release idempotency key", slog.Any("error", err))
+			}
+			return
+		}
+
+		stored := record{
+			Hash:        hash,
+			Completed:   true,
+			Status:      c.Writer.Status(),
+			ContentType: c.Writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		}
+		if err := store.SetWithTTL(ctx, storeKey, stored, window); err != nil {
+			logger.ErrorContext(ctx, "failed store idempotent response", slog.Any("error", err))
+		}
+	}
+}
+
+// replay writes stored response of the key
+func replay(c *gin.Context, store cache.Cache, storeKey, hash string) {
+	ctx := c.Request.Context()
+
+	var stored record
+	if err := store.Get(ctx, storeKey, &stored); err != nil {
+		if errors.Is(err, cache.ErrorNotExists) {
+			// key is released between reserve and get
+			httperror.Abort(c, errorInProgress)
+			return
+		}
+
+		httperror.Abort(c, fmt.Errorf("failed get idempotent response: %w", err))
+		return
+	}
+
+	if stored.Hash != hash {
+		httperror.Abort(c, domain.NewConflictError(domain.CodeIdempotencyKeyReused, Header+" is already used with another request"))
+		return
+	}
+
+	if !stored.Completed {
+		httperror.Abort(c, errorInProgress)
+		return
+	}
+
+	c.Header(ReplayedHeader, "true")
+	c.Data(stored.Status, stored.ContentType, stored.Body)
+	c.Abort()
+}
+
+// scope returns owner of the key
+func scope(c *gin.Context) string {
+	if principal := domain.PrincipalFromContext(c.Request.Context()); principal != nil {
+		return principal.Subject
+	}
+
+	return "ip:" + c.ClientIP()
+}
+
+// requestHash returns hash of method, path, query and body of the request
+func requestHash(c *gin.Context, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "?" + c.Request.URL.RawQuery + "\n"))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// validKey reports whether key is non-empty printable ASCII string of allowed length
+func validKey(key string) bool {
+	if len(key) > maxKeyLength {
+		return false
+	}
+
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bodyWriter copies written body to be stored
+type bodyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,137 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"goods-manager/internal/cache"
+	"goods-manager/internal/httperror"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryCache implementation `cache.Cache` storing values in map, ttl is ignored.
+// Methods which are not used by middleware are not implemented.
+type memoryCache struct {
+	cache.Cache
+
+	mx    sync.Mutex
+	items map[string][]byte
+}
+
+func (m *memoryCache) Get(_ context.Context, key string, value interface{}) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	item, ok := m.items[key]
+	if !ok {
+		return cache.ErrorNotExists
+	}
+
+	return json.Unmarshal(item, value)
+}
+
+func (m *memoryCache) Set(ctx context.Context, key string, value interface{}) error {
+	return m.SetWithTTL(ctx, key, value, time.Minute)
+}
+
+func (m *memoryCache) SetWithTTL(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	item, err := json.Marshal(value)
+	m.items[key] = item
+	return err
+}
+
+func (m *memoryCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	m.mx.Lock()
+	_, ok := m.items[key]
+	m.mx.Unlock()
+
+	if ok {
+		return false, nil
+	}
+
+	return true, m.SetWithTTL(ctx, key, value, ttl)
+}
+
+func (m *memoryCache) Remove(_ context.Context, key string) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	delete(m.items, key)
+	return nil
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	store := &memoryCache{items: make(map[string][]byte)}
+	calls := 0
+	failing := true
+
+	r := gin.New()
+	r.Use(httperror.Middleware(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	r.Use(Middleware(store, time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil))))
+	r.POST("/good/create", func(c *gin.Context) {
+		calls++
+		body, _ := io.ReadAll(c.Request.Body)
+		c.JSON(http.StatusOK, gin.H{"call": calls, "body": string(body)})
+	})
+	r.POST("/good/fail", func(c *gin.Context) {
+		calls++
+		if failing {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	request := func(path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(Header, key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	first := request("/good/create?projectId=1", "key-1", `{"name":"a"}`)
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, 1, calls)
+
+	// retry is replayed
+	retry := request("/good/create?projectId=1", "key-1", `{"name":"a"}`)
+	assert.Equal(t, http.StatusOK, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", retry.Header().Get("Content-Type"))
+	assert.Equal(t, "true", retry.Header().Get(ReplayedHeader))
+	assert.Equal(t, 1, calls)
+
+	// key is reused with another body or query
+	assert.Equal(t, http.StatusConflict, request("/good/create?projectId=1", "key-1", `{"name":"b"}`).Code)
+	assert.Equal(t, http.StatusConflict, request("/good/create?projectId=2", "key-1", `{"name":"a"}`).Code)
+	assert.Equal(t, 1, calls)
+
+	// requests without key are not idempotent
+	request("/good/create?projectId=1", "", `{"name":"a"}`)
+	request("/good/create?projectId=1", "", `{"name":"a"}`)
+	assert.Equal(t, 3, calls)
+
+	assert.Equal(t, http.StatusBadRequest, request("/good/create?projectId=1", "bad\nkey", `{}`).Code)
+
+	// server errors are not stored
+	assert.Equal(t, http.StatusInternalServerError, request("/good/fail", "key-3", `{}`).Code)
+	failing = false
+	assert.Equal(t, http.StatusOK, request("/good/fail", "key-3", `{}`).Code)
+	assert.Equal(t, 5, calls)
+}
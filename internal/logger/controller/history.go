@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/httperror"
+	"goods-manager/internal/validation"
+	"net/http"
+)
+
+// HistoryController serves history of changes of goods stored by logger
+// by routes `/v2/projects/:projectId/goods[/:id]/history`
+type HistoryController struct {
+	loggerUsecase domain.LoggerUsecase
+}
+
+// GoodHistory this function is used for get history of changes of the good.
+//
+// @Summary		Get history of the good
+// @Description	Events of changes of the good with its state before and after them, the latest first.
+// @Description	History is stored asynchronously, so the latest changes may be missing.
+// @Tags		history
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		projectId	path		int			true	"Project ID"	minimum(1)
+// @Param		id			path		int			true	"Good ID"		minimum(1)
+// @Param		from		query		string		false	"Inclusive start of time range, RFC 3339"	format(date-time)
+// @Param		to			query		string		false	"Exclusive end of time range, RFC 3339"		format(date-time)
+// @Param		offset		query		int			false	"Offset of select"	minimum(0)	default(0)
+// @Param		limit		query		int			false	"Limit of rows"		minimum(1)	maximum(100)	default(20)
+//
+// @Success		200		{object}	HistoryResponse		"Events of the good"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/goods/{id}/history		[get]
+func (h *HistoryController) GoodHistory(c *gin.Context) {
+	var path GoodPath
+	if !validation.Bind(c, &path, c.ShouldBindUri) {
+		return
+	}
+
+	h.list(c, path.ProjectId, path.Id)
+}
+
+// ProjectHistory this function is used for get history of changes of goods of the project.
+//
+// @Summary		Get history of goods of the project
+// @Description	Events of changes of goods of the project with their states before and after them, the latest first.
+// @Description	History is stored asynchronously, so the latest changes may be missing.
+// @Tags		history
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		projectId	path		int			true	"Project ID"	minimum(1)
+// @Param		from		query		string		false	"Inclusive start of time range, RFC 3339"	format(date-time)
+// @Param		to			query		string		false	"Exclusive end of time range, RFC 3339"		format(date-time)
+// @Param		offset		query		int			false	"Offset of select"	minimum(0)	default(0)
+// @Param		limit		query		int			false	"Limit of rows"		minimum(1)	maximum(100)	default(20)
+//
+// @Success		200		{object}	HistoryResponse		"Events of goods of the project"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/goods/history		[get]
+func (h *HistoryController) ProjectHistory(c *gin.Context) {
+	var path ProjectPath
+	if !validation.Bind(c, &path, c.ShouldBindUri) {
+		return
+	}
+
+	h.list(c, path.ProjectId, 0)
+}
+
+// list responds page of history of the project or its good if `goodId` isn't zero
+func (h *HistoryController) list(c *gin.Context, projectId, goodId int) {
+	var query HistoryQuery
+	if !validation.Bind(c, &query, c.ShouldBindQuery) {
+		return
+	}
+
+	events, err := h.loggerUsecase.ListHistory(c, entity.HistoryFilter{
+		ProjectId: projectId,
+		GoodId:    goodId,
+		From:      query.From,
+		To:        query.To,
+		Limit:     query.Limit,
+		Offset:    query.Offset,
+	})
+	if err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, HistoryResponse{Events: events, Limit: query.Limit, Offset: query.Offset})
+}
+
+func NewHistoryController(loggerUsecase domain.LoggerUsecase) *HistoryController {
+	return &HistoryController{loggerUsecase: loggerUsecase}
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/httperror"
+	"goods-manager/internal/validation"
+	"goods-manager/mocks"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHistoryController(t *testing.T) {
+	from := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		url    string
+		filter *entity.HistoryFilter
+		code   int
+	}{
+		{
+			name:   "good",
+			url:    "/v2/projects/2/goods/5/history",
+			filter: &entity.HistoryFilter{ProjectId: 2, GoodId: 5, Limit: 20},
+			code:   http.StatusOK,
+		},
+		{
+			name:   "project in time range",
+			url:    "/v2/projects/2/goods/history?from=2026-10-01T00:00:00Z&to=2026-10-19T00:00:00Z&limit=10&offset=30",
+			filter: &entity.HistoryFilter{ProjectId: 2, From: from, To: to, Limit: 10, Offset: 30},
+			code:   http.StatusOK,
+		},
+		{
+			name: "reversed time range",
+			url:  "/v2/projects/2/goods/history?from=2026-10-19T00:00:00Z&to=2026-10-01T00:00:00Z",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "invalid time",
+			url:  "/v2/projects/2/goods/history?from=yesterday",
+			code: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			if err := validation.Register(); err != nil {
+				t.Fatal(err)
+			}
+
+			loggerUsecase := mocks.NewLoggerUsecase(t)
+			if tt.filter != nil {
+				loggerUsecase.On("ListHistory", mock.Anything, *tt.filter).Return([]*entity.Event{}, nil)
+			}
+
+			historyController := NewHistoryController(loggerUsecase)
+			r := gin.New()
+			r.Use(httperror.Middleware(slog.New(slog.NewTextHandler(io.Discard, nil))))
+			r.GET("/v2/projects/:projectId/goods/history", historyController.ProjectHistory)
+			r.GET("/v2/projects/:projectId/goods/:id/history", historyController.GoodHistory)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			assert.Equal(t, tt.code, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package controller
+
+import "time"
+
+type GoodPath struct {
+	ProjectId int `uri:"projectId" binding:"required,min=1"`
+	Id        int `uri:"id" binding:"required,min=1"`
+}
+
+type ProjectPath struct {
+	ProjectId int `uri:"projectId" binding:"required,min=1"`
+}
+
+// HistoryQuery is time range and page of history, empty bounds don't limit the range
+type HistoryQuery struct {
+	From   time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To     time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00" binding:"omitempty,gtfield=From"`
+	Limit  int       `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset int       `form:"offset,default=0" binding:"min=0"`
+}
@@ -0,0 +1,9 @@
+package controller
+
+import "goods-manager/internal/domain/entity"
+
+type HistoryResponse struct {
+	Events []*entity.Event `json:"events"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
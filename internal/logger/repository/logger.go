@@ -2,25 +2,151 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 	"goods-manager/internal/domain"
 	"goods-manager/internal/domain/entity"
+	"strings"
+	"time"
 )
 
 type loggerRepository struct {
 	conn driver.Conn
 }
 
-func (l *loggerRepository) SaveList(ctx context.Context, goods []*entity.Good) error {
-	query := `INSERT INTO goods (Id, ProjectId, Name, Description, Priority, Removed) VALUES `
+// SaveList inserts events with state of good after the change, previous state is stored as JSON.
+//
+// Insert waits for acknowledgement of ClickHouse, so failed insert is retried. Rows of retried and redelivered
+// events have the same event ID, `ReplacingMergeTree` merges them and `goods_history` hides not merged ones.
+func (l *loggerRepository) SaveList(ctx context.Context, events []*entity.Event) error {
+	query := `INSERT INTO goods (EventId, EventType, EventTime, Actor, Id, ProjectId, Name, Description, Priority, Removed, Previous) VALUES `
 	var values []interface{}
 
-	for _, goods := range goods {
-		query += "(?, ?, ?, ?, ?, ?),"
-		values = append(values, goods.Id, goods.ProjectId, goods.Name, goods.Description, goods.Priority, goods.Removed)
+	for _, event := range events {
+		var previous *string
+		if event.Previous != nil {
+			data, err := json.Marshal(event.Previous)
+			if err != nil {
+				return err
+			}
+			state := string(data)
+			previous = &state
+		}
+
+		good := event.Payload
+		query += "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?),"
+		values = append(values, event.Id, string(event.Type), event.OccurredAt, event.Actor,
+			good.Id, good.ProjectId, good.Name, good.Description, good.Priority, good.Removed, previous)
+	}
+
+	return l.conn.AsyncInsert(ctx, query, true, values...)
+}
+
+// ListHistory selects page of events from deduplicated `goods_history` view
+func (l *loggerRepository) ListHistory(ctx context.Context, filter entity.HistoryFilter) (_ []*entity.Event, err error) {
+	conditions := []string{"ProjectId = ?"}
+	args := []any{filter.ProjectId}
+	if filter.GoodId != 0 {
+		conditions = append(conditions, "Id = ?")
+		args = append(args, filter.GoodId)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "EventTime >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "EventTime < ?")
+		args = append(args, filter.To)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT EventId, EventType, EventTime, Actor, Id, ProjectId, Name, Description, Priority, Removed, Previous
+		FROM goods_history
+		WHERE %s
+		ORDER BY EventTime DESC, EventId DESC
+		LIMIT ? OFFSET ?
+	`, strings.Join(conditions, " AND "))
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := l.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed close rows: %w", closeErr)
+		}
+	}()
+
+	events := make([]*entity.Event, 0)
+	for rows.Next() {
+		var row eventRow
+		if err := rows.ScanStruct(&row); err != nil {
+			return nil, err
+		}
+
+		event, err := row.event()
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// eventRow is row of `goods` table
+type eventRow struct {
+	EventId     string    `ch:"EventId"`
+	EventType   string    `ch:"EventType"`
+	EventTime   time.Time `ch:"EventTime"`
+	Actor       string    `ch:"Actor"`
+	Id          int32     `ch:"Id"`
+	ProjectId   int32     `ch:"ProjectId"`
+	Name        string    `ch:"Name"`
+	Description *string   `ch:"Description"`
+	Priority    int32     `ch:"Priority"`
+	Removed     bool      `ch:"Removed"`
+	Previous    *string   `ch:"Previous"`
+}
+
+// event converts row to event envelope of the current version
+func (r eventRow) event() (*entity.Event, error) {
+	good := &entity.Good{
+		Id:        int(r.Id),
+		ProjectId: int(r.ProjectId),
+		Name:      r.Name,
+		Priority:  int(r.Priority),
+		Removed:   r.Removed,
+	}
+	if r.Description != nil {
+		good.Description = *r.Description
+	}
+
+	event := &entity.Event{
+		Version:    entity.EventVersion,
+		Id:         r.EventId,
+		Type:       entity.EventType(r.EventType),
+		OccurredAt: r.EventTime.UTC(),
+		Actor:      r.Actor,
+		ProjectId:  good.ProjectId,
+		Payload:    good,
+	}
+
+	if r.Previous != nil {
+		if err := json.Unmarshal([]byte(*r.Previous), &event.Previous); err != nil {
+			return nil, fmt.Errorf("failed unmarshal previous state of event %s: %w", r.EventId, err)
+		}
 	}
 
-	return l.conn.AsyncInsert(ctx, query, false, values...)
+	return event, nil
 }
 
 func NewLoggerRepository(conn driver.Conn) domain.LoggerRepository {
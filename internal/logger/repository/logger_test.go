@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/domain/entity"
+	"testing"
+	"time"
+)
+
+func Test_eventRow_event(t *testing.T) {
+	description := "new"
+	previous := `{"id":5,"project_id":2,"name":"old","description":"","priority":1,"removed":false,"created_at":""}`
+	occurredAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	event, err := eventRow{
+		EventId: "id", EventType: "good.updated", EventTime: occurredAt.In(time.Local), Actor: "api_key:1",
+		Id: 5, ProjectId: 2, Name: "new", Description: &description, Priority: 1, Previous: &previous,
+	}.event()
+	require.NoError(t, err)
+
+	assert.Equal(t, &entity.Event{
+		Version:    entity.EventVersion,
+		Id:         "id",
+		Type:       entity.EventGoodUpdated,
+		OccurredAt: occurredAt,
+		Actor:      "api_key:1",
+		ProjectId:  2,
+		Payload:    &entity.Good{Id: 5, ProjectId: 2, Name: "new", Description: "new", Priority: 1},
+		Previous:   &entity.Good{Id: 5, ProjectId: 2, Name: "old", Priority: 1},
+	}, event)
+
+	_, err = eventRow{EventId: "id", Previous: &description}.event()
+	assert.Error(t, err)
+}
@@ -3,32 +3,95 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"goods-manager/internal/domain"
 	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/logging"
+	"goods-manager/internal/tracing"
+	"time"
 )
 
-const Subject = "logger:good"
+const (
+	Subject = "logger:good"
+
+	// ReplaySubject is subject of replayed dead letters, only logger listens it,
+	// so change stream and its clients don't receive old events again
+	ReplaySubject = "logger:good:replay"
+
+	// EventIdHeader is header with time-ordered ID (UUIDv7) of message,
+	// all subscribers see the same ID of the message
+	EventIdHeader = "Event-Id"
+)
 
 type loggerUsecase struct {
-	nc         *nats.Conn
-	loggerRepo domain.LoggerRepository
+	outboxUsecase domain.OutboxUsecase
+	loggerRepo    domain.LoggerRepository
 }
 
-// SendToQueue send message to `Subject`
-func (l *loggerUsecase) SendToQueue(_ context.Context, good *entity.Good) error {
-	data, err := json.Marshal(good)
+// SendToQueue enqueues event envelope to `Subject` to outbox, it is published after commit of the transaction.
+//
+// Actor is principal of the context.
+// Trace context, request ID and event ID are injected into message headers.
+func (l *loggerUsecase) SendToQueue(ctx context.Context, eventType entity.EventType, good, previous *entity.Good) (err error) {
+	ctx, span := tracing.Start(ctx, "loggerUsecase.SendToQueue",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("messaging.system", "nats"), attribute.String("messaging.destination.name", Subject)),
+	)
+	defer tracing.End(span, &err)
+
+	eventId, err := uuid.NewV7()
 	if err != nil {
 		return err
 	}
 
-	return l.nc.Publish(Subject, data)
+	event := entity.Event{
+		Version:    entity.EventVersion,
+		Id:         eventId.String(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		ProjectId:  good.ProjectId,
+		Payload:    good,
+		Previous:   previous,
+	}
+	if principal := domain.PrincipalFromContext(ctx); principal != nil {
+		event.Actor = principal.Subject
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(Subject)
+	msg.Data = data
+	msg.Header.Set(EventIdHeader, event.Id)
+	// JetStream drops duplicates published again by outbox relay
+	msg.Header.Set(nats.MsgIdHdr, event.Id)
+	tracing.InjectNats(ctx, msg)
+	if requestID := logging.RequestIDFromContext(ctx); requestID != "" {
+		msg.Header.Set(logging.RequestIDHeader, requestID)
+	}
+
+	return l.outboxUsecase.Enqueue(ctx, &entity.OutboxMessage{Subject: msg.Subject, Header: msg.Header, Data: msg.Data})
 }
 
-func (l *loggerUsecase) SaveList(ctx context.Context, goods []*entity.Good) error {
-	return l.loggerRepo.SaveList(ctx, goods)
+func (l *loggerUsecase) SaveList(ctx context.Context, events []*entity.Event) (err error) {
+	ctx, span := tracing.Start(ctx, "loggerUsecase.SaveList", trace.WithAttributes(attribute.Int("events.count", len(events))))
+	defer tracing.End(span, &err)
+
+	return l.loggerRepo.SaveList(ctx, events)
+}
+
+func (l *loggerUsecase) ListHistory(ctx context.Context, filter entity.HistoryFilter) (_ []*entity.Event, err error) {
+	ctx, span := tracing.Start(ctx, "loggerUsecase.ListHistory")
+	defer tracing.End(span, &err)
+
+	return l.loggerRepo.ListHistory(ctx, filter)
 }
 
-func NewLoggerUsecase(nc *nats.Conn, loggerRepo domain.LoggerRepository) domain.LoggerUsecase {
-	return &loggerUsecase{nc: nc, loggerRepo: loggerRepo}
+func NewLoggerUsecase(outboxUsecase domain.OutboxUsecase, loggerRepo domain.LoggerRepository) domain.LoggerUsecase {
+	return &loggerUsecase{outboxUsecase: outboxUsecase, loggerRepo: loggerRepo}
 }
@@ -0,0 +1,219 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel/trace"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/logger/usecase"
+	"goods-manager/internal/tracing"
+	"log/slog"
+	"time"
+)
+
+const (
+	DefaultStream     = "LOGGER"
+	DefaultConsumer   = "logger"
+	DefaultMaxDeliver = 5
+	DefaultAckWait    = 30 * time.Second
+
+	// jetStreamBatch is max count of messages saved by one insert
+	jetStreamBatch = 100
+
+	// jetStreamMaxWait is max time to wait for full batch
+	jetStreamMaxWait = 1 * time.Second
+
+	// saveTimeout is max time of insert of batch
+	saveTimeout = 10 * time.Second
+)
+
+// JetStreamConfig is configuration of logger pipeline on NATS JetStream, zero values are defaults
+type JetStreamConfig struct {
+	// Enabled switches logger from core NATS subscription to JetStream consumer
+	Enabled bool
+
+	// Stream is name of durable stream of `usecase.Subject`
+	Stream string
+
+	// Consumer is name of durable pull consumer
+	Consumer string
+
+	// MaxDeliver is max count of deliveries of message, message goes to dead letters after it
+	MaxDeliver int
+
+	// AckWait is time to wait for ack before redelivery
+	AckWait time.Duration
+}
+
+// withDefaults returns config with defaults instead of zero values
+func (c JetStreamConfig) withDefaults() JetStreamConfig {
+	if c.Stream == "" {
+		c.Stream = DefaultStream
+	}
+	if c.Consumer == "" {
+		c.Consumer = DefaultConsumer
+	}
+	if c.MaxDeliver <= 0 {
+		c.MaxDeliver = DefaultMaxDeliver
+	}
+	if c.AckWait <= 0 {
+		c.AckWait = DefaultAckWait
+	}
+
+	return c
+}
+
+// JetStreamWorker saves events of durable stream to store.
+//
+// Messages are acked after batch is saved, so they are redelivered after restart or failure of the store.
+// Messages which can't be decoded and messages of failed batch delivered `MaxDeliver` times go to dead letters.
+type JetStreamWorker struct {
+	consumer          jetstream.Consumer
+	loggerUsecase     domain.LoggerUsecase
+	deadLetterUsecase domain.DeadLetterUsecase
+	config            JetStreamConfig
+	logger            *slog.Logger
+}
+
+// Run fetches and saves batches of messages until context is done
+func (j *JetStreamWorker) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		batch, err := j.consumer.Fetch(jetStreamBatch, jetstream.FetchMaxWait(jetStreamMaxWait))
+		if err != nil {
+			j.logger.ErrorContext(ctx, "failed fetch events from stream", slog.String("stream", j.config.Stream), slog.Any("error", err))
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(jetStreamMaxWait):
+			}
+			continue
+		}
+
+		msgs := make([]jetstream.Msg, 0, jetStreamBatch)
+		for msg := range batch.Messages() {
+			msgs = append(msgs, msg)
+		}
+
+		if err := batch.Error(); err != nil && !errors.Is(err, nats.ErrTimeout) {
+			j.logger.WarnContext(ctx, "fetch of events is interrupted", slog.Any("error", err))
+		}
+
+		j.save(ctx, msgs)
+	}
+}
+
+// save saves events of the messages by one insert and acks them, failed messages are redelivered after `AckWait`
+func (j *JetStreamWorker) save(ctx context.Context, msgs []jetstream.Msg) {
+	if len(msgs) == 0 {
+		return
+	}
+
+	events := make([]*entity.Event, 0, len(msgs))
+	received := make([]jetstream.Msg, 0, len(msgs))
+	links := make([]trace.Link, 0, len(msgs))
+	for _, msg := range msgs {
+		event, spanContext, err := receive(natsMsg(msg), j.logger)
+		if err != nil {
+			// redelivery doesn't fix malformed message
+			j.kill(ctx, msg, err)
+			continue
+		}
+
+		events = append(events, event)
+		received = append(received, msg)
+		links = append(links, trace.Link{SpanContext: spanContext})
+	}
+
+	if len(events) == 0 {
+		return
+	}
+
+	saveCtx, cancel := context.WithTimeout(ctx, saveTimeout)
+	defer cancel()
+
+	saveCtx, span := tracing.Start(saveCtx, "JetStreamWorker.Flush", trace.WithLinks(links...))
+	err := j.loggerUsecase.SaveList(saveCtx, events)
+	tracing.End(span, &err)
+
+	if err != nil {
+		j.logger.ErrorContext(ctx, "failed to save list of events, they are redelivered", slog.Int("count", len(events)), slog.Any("error", err))
+
+		for _, msg := range received {
+			if deliveries(msg) >= j.config.MaxDeliver {
+				j.kill(ctx, msg, err)
+				continue
+			}
+
+			if err := msg.NakWithDelay(j.config.AckWait); err != nil {
+				j.logger.ErrorContext(ctx, "failed nak event", slog.Any("error", err))
+			}
+		}
+		return
+	}
+
+	for _, msg := range received {
+		if err := msg.Ack(); err != nil {
+			// message is redelivered and saved again
+			j.logger.ErrorContext(ctx, "failed ack event", slog.Any("error", err))
+		}
+	}
+}
+
+// kill moves the message to dead letters and terminates it, message is redelivered if it can't be stored
+func (j *JetStreamWorker) kill(ctx context.Context, msg jetstream.Msg, reason error) {
+	if !addDeadLetter(ctx, j.deadLetterUsecase, natsMsg(msg), reason, deliveries(msg), j.logger) {
+		if err := msg.NakWithDelay(j.config.AckWait); err != nil {
+			j.logger.ErrorContext(ctx, "failed nak event", slog.Any("error", err))
+		}
+		return
+	}
+
+	if err := msg.TermWithReason(reason.Error()); err != nil {
+		j.logger.ErrorContext(ctx, "failed terminate dead event", slog.Any("error", err))
+	}
+}
+
+// deliveries returns count of deliveries of the message, 1 if it is unknown
+func deliveries(msg jetstream.Msg) int {
+	metadata, err := msg.Metadata()
+	if err != nil {
+		return 1
+	}
+
+	return int(metadata.NumDelivered)
+}
+
+func natsMsg(msg jetstream.Msg) *nats.Msg {
+	return &nats.Msg{Subject: msg.Subject(), Header: msg.Headers(), Data: msg.Data()}
+}
+
+// NewJetStreamWorker creates or updates durable stream of `usecase.Subject` and `usecase.ReplaySubject`
+// and its pull consumer of both subjects
+func NewJetStreamWorker(ctx context.Context, js jetstream.JetStream, config JetStreamConfig, loggerUsecase domain.LoggerUsecase, deadLetterUsecase domain.DeadLetterUsecase, logger *slog.Logger) (*JetStreamWorker, error) {
+	config = config.withDefaults()
+
+	_, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     config.Stream,
+		Subjects: []string{usecase.Subject, usecase.ReplaySubject},
+		Storage:  jetstream.FileStorage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	consumer, err := js.CreateOrUpdateConsumer(ctx, config.Stream, jetstream.ConsumerConfig{
+		Durable:        config.Consumer,
+		AckPolicy:      jetstream.AckExplicitPolicy,
+		AckWait:        config.AckWait,
+		MaxDeliver:     config.MaxDeliver,
+		FilterSubjects: []string{usecase.Subject, usecase.ReplaySubject},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &JetStreamWorker{consumer: consumer, loggerUsecase: loggerUsecase, deadLetterUsecase: deadLetterUsecase, config: config, logger: logger}, nil
+}
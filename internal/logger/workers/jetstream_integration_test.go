@@ -0,0 +1,105 @@
+//go:build integration
+
+package workers
+
+import (
+	"context"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/logger/usecase"
+	"goods-manager/mocks"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestJetStreamWorker_integration runs worker against NATS server with JetStream at `NATS_TEST_URL`,
+// e.g. `docker-compose up -d nats && NATS_TEST_URL=nats://localhost:4222 go test -tags integration ./internal/logger/workers`.
+// Stream of the test captures `usecase.Subject` and `usecase.ReplaySubject`, so server must not have another stream of them.
+func TestJetStreamWorker_integration(t *testing.T) {
+	url := os.Getenv("NATS_TEST_URL")
+	if url == "" {
+		t.Skip("NATS_TEST_URL is not set")
+	}
+
+	nc, err := nats.Connect(url)
+	require.NoError(t, err)
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	config := JetStreamConfig{Enabled: true, Stream: "LOGGER_TEST_" + strconv.FormatInt(time.Now().UnixNano(), 10), Consumer: "logger_test", MaxDeliver: 3, AckWait: time.Second}
+	t.Cleanup(func() { _ = js.DeleteStream(context.Background(), config.Stream) })
+
+	// the first insert fails, so the event is redelivered and saved by the second one
+	saved := make(chan []*entity.Event, 1)
+	loggerUsecase := mocks.NewLoggerUsecase(t)
+	loggerUsecase.On("SaveList", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+	loggerUsecase.On("SaveList", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { saved <- args.Get(1).([]*entity.Event) }).
+		Return(nil).Once()
+
+	// malformed message goes to dead letters
+	dead := make(chan *entity.DeadLetter, 1)
+	deadLetterUsecase := mocks.NewDeadLetterUsecase(t)
+	deadLetterUsecase.On("Add", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { dead <- args.Get(1).(*entity.DeadLetter) }).
+		Return(nil).Once()
+
+	worker, err := NewJetStreamWorker(ctx, js, config, loggerUsecase, deadLetterUsecase, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	// published again by outbox relay, stream drops the duplicate by its ID
+	event := &nats.Msg{Subject: usecase.Subject, Header: nats.Header{}, Data: []byte(`{"version":1,"event_id":"1","type":"good.created","payload":{"id":1}}`)}
+	event.Header.Set(nats.MsgIdHdr, "1")
+	for i := 0; i < 2; i++ {
+		_, err = js.PublishMsg(ctx, event)
+		require.NoError(t, err)
+	}
+	_, err = js.Publish(ctx, usecase.Subject, []byte(`{"id":2}`))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	runCtx, stop := context.WithCancel(ctx)
+	go func() {
+		defer close(done)
+		worker.Run(runCtx)
+	}()
+
+	select {
+	case events := <-saved:
+		require.Len(t, events, 1)
+		assert.Equal(t, "1", events[0].Id)
+	case <-ctx.Done():
+		t.Fatal("event isn't saved")
+	}
+
+	select {
+	case deadLetter := <-dead:
+		assert.Equal(t, []byte(`{"id":2}`), deadLetter.Data)
+	case <-ctx.Done():
+		t.Fatal("malformed message isn't moved to dead letters")
+	}
+
+	stop()
+	<-done
+
+	// saved event is acked and terminated message isn't redelivered
+	consumer, err := js.Consumer(ctx, config.Stream, config.Consumer)
+	require.NoError(t, err)
+	info, err := consumer.Info(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, info.NumAckPending)
+	assert.Zero(t, info.NumPending)
+}
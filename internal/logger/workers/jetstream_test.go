@@ -0,0 +1,94 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/mocks"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// testMsg records acknowledgement of JetStream message
+type testMsg struct {
+	jetstream.Msg
+	data      []byte
+	delivered uint64
+	ack       string
+}
+
+func (m *testMsg) Data() []byte         { return m.data }
+func (m *testMsg) Headers() nats.Header { return nats.Header{} }
+func (m *testMsg) Subject() string      { return "logger:good" }
+func (m *testMsg) Metadata() (*jetstream.MsgMetadata, error) {
+	return &jetstream.MsgMetadata{NumDelivered: m.delivered}, nil
+}
+func (m *testMsg) Ack() error                       { m.ack = "ack"; return nil }
+func (m *testMsg) NakWithDelay(time.Duration) error { m.ack = "nak"; return nil }
+func (m *testMsg) TermWithReason(string) error      { m.ack = "term"; return nil }
+
+func TestJetStreamWorker_save(t *testing.T) {
+	newMsgs := func() []*testMsg {
+		return []*testMsg{
+			{data: []byte(`{"version":1,"event_id":"1","type":"good.created","payload":{"id":1}}`), delivered: 1},
+			{data: []byte(`{"id":2}`), delivered: 1},
+			{data: []byte(`{"version":1,"event_id":"3","type":"good.updated","payload":{"id":3}}`), delivered: 5},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		saveErr error
+		want    []string
+		// dead are data of dead letters
+		dead []string
+	}{
+		{name: "saved", want: []string{"ack", "term", "ack"}, dead: []string{`{"id":2}`}},
+		{
+			name:    "store failed",
+			saveErr: errors.New("clickhouse is down"),
+			// the last delivery of the third message
+			want: []string{"nak", "term", "term"},
+			dead: []string{`{"id":2}`, `{"version":1,"event_id":"3","type":"good.updated","payload":{"id":3}}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loggerUsecase := mocks.NewLoggerUsecase(t)
+			loggerUsecase.On("SaveList", mock.Anything, mock.MatchedBy(func(events []*entity.Event) bool {
+				return len(events) == 2 && events[0].Id == "1" && events[1].Id == "3"
+			})).Return(tt.saveErr)
+
+			var dead []string
+			deadLetterUsecase := mocks.NewDeadLetterUsecase(t)
+			deadLetterUsecase.On("Add", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { dead = append(dead, string(args.Get(1).(*entity.DeadLetter).Data)) }).
+				Return(nil)
+
+			worker := &JetStreamWorker{
+				loggerUsecase:     loggerUsecase,
+				deadLetterUsecase: deadLetterUsecase,
+				config:            JetStreamConfig{}.withDefaults(),
+				logger:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+			}
+
+			msgs := newMsgs()
+			batch := make([]jetstream.Msg, len(msgs))
+			for i, msg := range msgs {
+				batch[i] = msg
+			}
+			worker.save(context.Background(), batch)
+
+			for i, msg := range msgs {
+				assert.Equal(t, tt.want[i], msg.ack, "message %d", i)
+			}
+			assert.Equal(t, tt.dead, dead)
+		})
+	}
+}
@@ -3,69 +3,404 @@ package workers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"goods-manager/internal/domain"
 	"goods-manager/internal/domain/entity"
 	"goods-manager/internal/logger/usecase"
-	"log"
+	"goods-manager/internal/logging"
+	"goods-manager/internal/tracing"
+	"log/slog"
 	"sync"
 	"time"
 )
 
-// LoggerWorker struct for receive and save logs
+const (
+	DefaultBatchSize    = 1000
+	DefaultBatchLatency = 1 * time.Second
+	DefaultBufferSize   = 10000
+	DefaultFlushWorkers = 2
+	DefaultQueueGroup   = "logger"
+	DefaultPendingLimit = nats.DefaultSubPendingMsgsLimit
+
+	// saveAttempts is count of attempts to save batch before its events are dead
+	saveAttempts = 3
+)
+
+// OverflowPolicy is behavior of `LoggerWorker` when its buffer is full
+type OverflowPolicy string
+
+const (
+	// OverflowBlock blocks receiving until there is space in the buffer. NATS client keeps up to `PendingLimit`
+	// messages of the subscription meanwhile, messages over it are dropped by NATS as slow consumer and
+	// aren't dead lettered, the loss is only logged by async error handler of the connection.
+	OverflowBlock OverflowPolicy = "block"
+
+	// OverflowDropOldest drops the oldest event of the buffer
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+
+	// OverflowSpill stores the received event to dead letters, so it can be replayed
+	OverflowSpill OverflowPolicy = "spill"
+)
+
+func (p OverflowPolicy) Valid() bool {
+	switch p {
+	case OverflowBlock, OverflowDropOldest, OverflowSpill:
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	errBufferFull    = errors.New("logger buffer is full")
+	errWorkerStopped = errors.New("logger worker is stopped")
+)
+
+// LoggerConfig is configuration of `LoggerWorker`, zero values are defaults
+type LoggerConfig struct {
+	// QueueGroup is NATS queue group of workers, every event is received by one worker of the group
+	QueueGroup string
+
+	// MaxSize is max count of events saved by one insert, full batch is saved at once
+	MaxSize int
+
+	// MaxLatency is max time between receiving of the first event of batch and its saving
+	MaxLatency time.Duration
+
+	// BufferSize is max count of received events waiting for flush workers
+	BufferSize int
+
+	// Overflow is behavior when the buffer is full
+	Overflow OverflowPolicy
+
+	// FlushWorkers is count of batches saved concurrently
+	FlushWorkers int
+
+	// PendingLimit is max count of received messages which NATS client keeps while receiving is blocked
+	PendingLimit int
+}
+
+// withDefaults returns config with defaults instead of zero values
+func (c LoggerConfig) withDefaults() LoggerConfig {
+	if c.QueueGroup == "" {
+		c.QueueGroup = DefaultQueueGroup
+	}
+	if c.MaxSize <= 0 {
+		c.MaxSize = DefaultBatchSize
+	}
+	if c.MaxLatency <= 0 {
+		c.MaxLatency = DefaultBatchLatency
+	}
+	if c.BufferSize <= 0 {
+		c.BufferSize = DefaultBufferSize
+	}
+	if c.Overflow == "" {
+		c.Overflow = OverflowBlock
+	}
+	if c.FlushWorkers <= 0 {
+		c.FlushWorkers = DefaultFlushWorkers
+	}
+	if c.PendingLimit <= 0 {
+		c.PendingLimit = DefaultPendingLimit
+	}
+
+	return c
+}
+
+// batchItem is received event with link to span of its receiving, so flush span continues its trace.
+// Received message is kept to store it unchanged to dead letters.
+type batchItem struct {
+	event *entity.Event
+	link  trace.Link
+	msg   *nats.Msg
+}
+
+// LoggerWorker struct for receive and save logs.
+//
+// Received events are buffered and saved in batches by flush workers, batch is saved when it is full
+// or its first event waits `MaxLatency`. Messages which can't be decoded, batches which can't be saved
+// after `saveAttempts` and events spilled by overflow of the buffer go to dead letters.
 type LoggerWorker struct {
-	nc            *nats.Conn
-	loggerUsecase domain.LoggerUsecase
+	nc                *nats.Conn
+	loggerUsecase     domain.LoggerUsecase
+	deadLetterUsecase domain.DeadLetterUsecase
+	config            LoggerConfig
+	logger            *slog.Logger
+
+	items chan batchItem
+	// mx guards closing of `items`, receivers hold it for reading
+	mx     sync.RWMutex
+	closed bool
+	// done is closed after the last batch is saved
+	done chan struct{}
 }
 
-// Run start listing `usecase.Subject` and save logs to store
-func (l *LoggerWorker) Run() error {
-	mx := sync.Mutex{}
-	buf := make([]*entity.Good, 0)
+// Run starts listing `usecase.Subject` and `usecase.ReplaySubject` in queue group and flush workers,
+// so instances don't save the same events. Listing stops when context is done, then buffered events are saved
+// and `Wait` returns.
+func (l *LoggerWorker) Run(ctx context.Context) error {
+	subscriptions := make([]*nats.Subscription, 0, 2)
+	for _, subject := range []string{usecase.Subject, usecase.ReplaySubject} {
+		s, err := l.nc.QueueSubscribe(subject, l.config.QueueGroup, func(m *nats.Msg) {
+			l.push(ctx, m)
+		})
+		if err != nil {
+			l.unsubscribe(subscriptions)
+			close(l.done)
+			return err
+		}
+
+		subscriptions = append(subscriptions, s)
+		if err := s.SetPendingLimits(l.config.PendingLimit, nats.DefaultSubPendingBytesLimit); err != nil {
+			l.unsubscribe(subscriptions)
+			close(l.done)
+			return err
+		}
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < l.config.FlushWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.batch()
+		}()
+	}
 
 	go func() {
+		<-ctx.Done()
+		l.unsubscribe(subscriptions)
+
+		l.stop()
+		wg.Wait()
+		close(l.done)
+	}()
+
+	l.logger.Info("worker logger is valid", slog.String("subject", usecase.Subject), slog.String("replay_subject", usecase.ReplaySubject), slog.String("queue_group", l.config.QueueGroup), slog.Int("flush_workers", l.config.FlushWorkers))
+	return nil
+}
+
+func (l *LoggerWorker) unsubscribe(subscriptions []*nats.Subscription) {
+	for _, s := range subscriptions {
+		if err := s.Unsubscribe(); err != nil {
+			l.logger.Error("failed unsubscribe logger worker", slog.String("subject", s.Subject), slog.Any("error", err))
+		}
+	}
+}
+
+// Wait waits until buffered events are saved after stop
+func (l *LoggerWorker) Wait() {
+	<-l.done
+}
+
+// stop closes the buffer, events received after it are spilled
+func (l *LoggerWorker) stop() {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.closed = true
+	close(l.items)
+}
+
+// push decodes event of the message and puts it to the buffer by overflow policy
+func (l *LoggerWorker) push(ctx context.Context, m *nats.Msg) {
+	event, spanContext, err := receive(m, l.logger)
+	if err != nil {
+		addDeadLetter(context.Background(), l.deadLetterUsecase, m, err, 1, l.logger)
+		return
+	}
+
+	item := batchItem{event: event, link: trace.Link{SpanContext: spanContext}, msg: m}
+
+	l.mx.RLock()
+	defer l.mx.RUnlock()
+
+	if l.closed {
+		addDeadLetter(context.Background(), l.deadLetterUsecase, m, errWorkerStopped, 0, l.logger)
+		return
+	}
+
+	switch l.config.Overflow {
+	case OverflowDropOldest:
 		for {
-			mx.Lock()
-			if len(buf) == 0 {
-				mx.Unlock()
-				continue
+			select {
+			case l.items <- item:
+				return
+			default:
+			}
+
+			select {
+			case dropped := <-l.items:
+				l.logger.Warn("logger buffer is full, the oldest event is dropped", slog.String("event_id", dropped.event.Id))
+			default:
+			}
+		}
+	case OverflowSpill:
+		select {
+		case l.items <- item:
+		default:
+			addDeadLetter(context.Background(), l.deadLetterUsecase, m, errBufferFull, 0, l.logger)
+		}
+	default:
+		// pending messages of the subscription are dropped by NATS over `PendingLimit` while it blocks
+		select {
+		case l.items <- item:
+		case <-ctx.Done():
+			// buffer is closed after the blocked receivers returned
+			addDeadLetter(context.Background(), l.deadLetterUsecase, m, errWorkerStopped, 0, l.logger)
+		}
+	}
+}
+
+// batch collects events of the buffer to batches and saves them until the buffer is closed
+func (l *LoggerWorker) batch() {
+	items := make([]batchItem, 0, l.config.MaxSize)
+
+	timer := time.NewTimer(l.config.MaxLatency)
+	timer.Stop()
+	// deadline is nil while batch is empty
+	var deadline <-chan time.Time
+
+	flush := func() {
+		if !timer.Stop() && deadline != nil {
+			select {
+			case <-timer.C:
+			default:
 			}
+		}
+		deadline = nil
 
-			events := make([]*entity.Good, len(buf))
-			copy(events, buf)
-			buf = buf[:0]
-			mx.Unlock()
+		if len(items) == 0 {
+			return
+		}
 
-			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-			defer cancel()
+		l.flush(items)
+		items = make([]batchItem, 0, l.config.MaxSize)
+	}
+
+	for {
+		select {
+		case item, ok := <-l.items:
+			if !ok {
+				flush()
+				return
+			}
 
-			if err := l.loggerUsecase.SaveList(ctx, events); err != nil {
-				log.Println("failed to save list of goods log", err)
+			items = append(items, item)
+			if len(items) == 1 {
+				timer.Reset(l.config.MaxLatency)
+				deadline = timer.C
 			}
 
-			time.Sleep(1 * time.Second)
+			if len(items) >= l.config.MaxSize {
+				flush()
+			}
+		case <-deadline:
+			deadline = nil
+			flush()
 		}
-	}()
+	}
+}
+
+// flush saves events by `saveAttempts` attempts, received messages of failed batch go to dead letters
+func (l *LoggerWorker) flush(items []batchItem) {
+	events := make([]*entity.Event, 0, len(items))
+	links := make([]trace.Link, 0, len(items))
+	for _, item := range items {
+		events = append(events, item.event)
+		links = append(links, item.link)
+	}
+
+	var err error
+	for attempt := 1; attempt <= saveAttempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		ctx, span := tracing.Start(ctx, "LoggerWorker.Flush", trace.WithLinks(links...))
+		err = l.loggerUsecase.SaveList(ctx, events)
+		tracing.End(span, &err)
+		cancel()
 
-	s, err := l.nc.Subscribe(usecase.Subject, func(m *nats.Msg) {
-		// receive new message. Unmarshal and send to channel
-		var good entity.Good
-		if err := json.Unmarshal(m.Data, &good); err != nil {
-			log.Println("failed unmarshal data from nats:", err)
+		if err == nil {
+			return
 		}
 
-		mx.Lock()
-		buf = append(buf, &good)
-		mx.Unlock()
-	})
+		l.logger.Error("failed to save list of events", slog.Int("count", len(events)), slog.Int("attempt", attempt), slog.Any("error", err))
+		if attempt < saveAttempts {
+			time.Sleep(time.Duration(attempt) * time.Second)
+		}
+	}
+
+	for _, item := range items {
+		addDeadLetter(context.Background(), l.deadLetterUsecase, item.msg, err, saveAttempts, l.logger)
+	}
+}
+
+// addDeadLetter stores the message with the reason, it returns false if message can't be stored
+func addDeadLetter(ctx context.Context, deadLetterUsecase domain.DeadLetterUsecase, m *nats.Msg, reason error, attempts int, logger *slog.Logger) bool {
+	deadLetter := entity.DeadLetter{Subject: m.Subject, Header: m.Header, Data: m.Data, Reason: reason.Error(), Attempts: attempts}
+	if err := deadLetterUsecase.Add(ctx, &deadLetter); err != nil {
+		logger.ErrorContext(ctx, "failed store dead letter", slog.String("reason", deadLetter.Reason), slog.Any("error", err))
+		return false
+	}
+
+	return true
+}
+
+// receive decodes event of the message in span which continues trace of the producer.
+// It returns context of the span to link it to span of saving.
+func receive(m *nats.Msg, logger *slog.Logger) (*entity.Event, trace.SpanContext, error) {
+	ctx := tracing.ExtractNats(context.Background(), m)
+	if requestID := m.Header.Get(logging.RequestIDHeader); requestID != "" {
+		ctx = logging.WithRequestID(ctx, requestID)
+	}
+
+	ctx, span := tracing.Start(ctx, "LoggerWorker.Receive",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attribute.String("messaging.system", "nats"), attribute.String("messaging.source.name", m.Subject)),
+	)
 
-	if s.IsValid() {
-		log.Println("worker logger is valid")
+	event, err := decodeEvent(m.Data)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed unmarshal data from nats", slog.Any("error", err))
+	} else {
+		span.SetAttributes(attribute.String("event.id", event.Id), attribute.String("event.type", string(event.Type)))
 	}
+	tracing.End(span, &err)
 
-	return err
+	return event, span.SpanContext(), err
 }
 
-func NewLoggerWorker(nc *nats.Conn, loggerUsecase domain.LoggerUsecase) *LoggerWorker {
-	return &LoggerWorker{nc: nc, loggerUsecase: loggerUsecase}
+// decodeEvent decodes event envelope, events of unknown version are rejected
+func decodeEvent(data []byte) (*entity.Event, error) {
+	var event entity.Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, err
+	}
+
+	if event.Version != entity.EventVersion {
+		return nil, fmt.Errorf("unsupported event version %d", event.Version)
+	}
+
+	if event.Payload == nil {
+		return nil, fmt.Errorf("event %s has no payload", event.Id)
+	}
+
+	return &event, nil
+}
+
+func NewLoggerWorker(nc *nats.Conn, config LoggerConfig, loggerUsecase domain.LoggerUsecase, deadLetterUsecase domain.DeadLetterUsecase, logger *slog.Logger) *LoggerWorker {
+	config = config.withDefaults()
+
+	return &LoggerWorker{
+		nc:                nc,
+		loggerUsecase:     loggerUsecase,
+		deadLetterUsecase: deadLetterUsecase,
+		config:            config,
+		logger:            logger,
+		items:             make(chan batchItem, config.BufferSize),
+		done:              make(chan struct{}),
+	}
 }
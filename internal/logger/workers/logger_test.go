@@ -0,0 +1,158 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/mocks"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_decodeEvent(t *testing.T) {
+	event, err := decodeEvent([]byte(`{"version":1,"event_id":"id","type":"good.updated","actor":"api_key:1","project_id":2,
+		"payload":{"id":5,"project_id":2,"name":"new"},"previous":{"id":5,"project_id":2,"name":"old"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, entity.EventGoodUpdated, event.Type)
+	assert.Equal(t, "api_key:1", event.Actor)
+	assert.Equal(t, "new", event.Payload.Name)
+	assert.Equal(t, "old", event.Previous.Name)
+
+	// bare good published before envelope
+	_, err = decodeEvent([]byte(`{"id":5,"project_id":2,"name":"name"}`))
+	assert.Error(t, err)
+
+	_, err = decodeEvent([]byte(`{"version":2,"event_id":"id","payload":{"id":5}}`))
+	assert.Error(t, err)
+
+	_, err = decodeEvent([]byte(`{"version":1,"event_id":"id"}`))
+	assert.Error(t, err)
+}
+
+func testEventMsg(id int) *nats.Msg {
+	return &nats.Msg{
+		Subject: "logger:good",
+		Header:  nats.Header{},
+		Data:    []byte(fmt.Sprintf(`{"version":1,"event_id":"%d","type":"good.updated","payload":{"id":%d}}`, id, id)),
+	}
+}
+
+func TestLoggerWorker_batch(t *testing.T) {
+	mx := sync.Mutex{}
+	var batches [][]string
+	loggerUsecase := mocks.NewLoggerUsecase(t)
+	loggerUsecase.On("SaveList", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			var ids []string
+			for _, event := range args.Get(1).([]*entity.Event) {
+				ids = append(ids, event.Id)
+			}
+			mx.Lock()
+			batches = append(batches, ids)
+			mx.Unlock()
+		}).
+		Return(nil)
+
+	worker := NewLoggerWorker(nil, LoggerConfig{MaxSize: 2, MaxLatency: 50 * time.Millisecond, FlushWorkers: 1},
+		loggerUsecase, mocks.NewDeadLetterUsecase(t), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	go func() {
+		worker.batch()
+		close(worker.done)
+	}()
+
+	for id := 1; id <= 3; id++ {
+		worker.push(context.Background(), testEventMsg(id))
+	}
+
+	// full batch is saved at once, the rest after latency
+	assert.Eventually(t, func() bool {
+		mx.Lock()
+		defer mx.Unlock()
+		return len(batches) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	worker.push(context.Background(), testEventMsg(4))
+	worker.stop()
+	worker.Wait()
+
+	assert.Equal(t, [][]string{{"1", "2"}, {"3"}, {"4"}}, batches)
+}
+
+func TestLoggerWorker_flush(t *testing.T) {
+	loggerUsecase := mocks.NewLoggerUsecase(t)
+	loggerUsecase.On("SaveList", mock.Anything, mock.Anything).Return(errors.New("clickhouse is down")).Times(saveAttempts)
+
+	msg := testEventMsg(1)
+	msg.Subject = "logger:good:replay"
+	msg.Header.Set("Event-Id", "1")
+	msg.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	msg.Header.Set("X-Request-ID", "request-1")
+
+	var dead []*entity.DeadLetter
+	deadLetterUsecase := mocks.NewDeadLetterUsecase(t)
+	deadLetterUsecase.On("Add", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { dead = append(dead, args.Get(1).(*entity.DeadLetter)) }).
+		Return(nil)
+
+	worker := NewLoggerWorker(nil, LoggerConfig{}, loggerUsecase, deadLetterUsecase, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	worker.push(context.Background(), msg)
+	worker.flush([]batchItem{<-worker.items})
+
+	// dead letter is the received message, so replay continues its trace and request
+	require.Len(t, dead, 1)
+	assert.Equal(t, msg.Subject, dead[0].Subject)
+	assert.Equal(t, map[string][]string(msg.Header), dead[0].Header)
+	assert.Equal(t, msg.Data, dead[0].Data)
+	assert.Equal(t, saveAttempts, dead[0].Attempts)
+}
+
+func TestLoggerWorker_push(t *testing.T) {
+	tests := []struct {
+		overflow OverflowPolicy
+		// buffered are IDs of events in the buffer
+		buffered []string
+		// dead are data of dead letters
+		dead []string
+	}{
+		{overflow: OverflowDropOldest, buffered: []string{"2", "3"}},
+		{overflow: OverflowSpill, buffered: []string{"1", "2"}, dead: []string{string(testEventMsg(3).Data)}},
+		{overflow: OverflowBlock, buffered: []string{"1", "2"}, dead: []string{string(testEventMsg(3).Data)}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.overflow), func(t *testing.T) {
+			var dead []string
+			deadLetterUsecase := mocks.NewDeadLetterUsecase(t)
+			if tt.dead != nil {
+				deadLetterUsecase.On("Add", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) { dead = append(dead, string(args.Get(1).(*entity.DeadLetter).Data)) }).
+					Return(nil)
+			}
+
+			worker := NewLoggerWorker(nil, LoggerConfig{BufferSize: 2, Overflow: tt.overflow},
+				mocks.NewLoggerUsecase(t), deadLetterUsecase, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			// blocked receiving is interrupted by stop of the worker
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			for id := 1; id <= 3; id++ {
+				worker.push(ctx, testEventMsg(id))
+			}
+
+			worker.stop()
+			var buffered []string
+			for item := range worker.items {
+				buffered = append(buffered, item.event.Id)
+			}
+			assert.Equal(t, tt.buffered, buffered)
+			assert.Equal(t, tt.dead, dead)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNew_JSONWithRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, FormatJSON, "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.InfoContext(WithRequestID(context.Background(), "req-1"), "hello")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "hello", record["msg"])
+	assert.Equal(t, "req-1", record["request_id"])
+}
+
+func TestNew_InvalidOptions(t *testing.T) {
+	_, err := New(&bytes.Buffer{}, "xml", "")
+	assert.Error(t, err)
+
+	_, err = New(&bytes.Buffer{}, FormatText, "verbose")
+	assert.Error(t, err)
+}
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		header   string
+		generate bool
+	}{
+		{name: "keep client id", header: "client-id-1"},
+		{name: "generate when missing", generate: true},
+		{name: "generate when invalid", header: "bad id\n", generate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromContext string
+
+			r := gin.New()
+			r.Use(RequestID())
+			r.GET("/", func(c *gin.Context) {
+				fromContext = RequestIDFromContext(c.Request.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			got := w.Header().Get(RequestIDHeader)
+			assert.Equal(t, got, fromContext)
+			if tt.generate {
+				assert.NotEmpty(t, got)
+				assert.NotEqual(t, tt.header, got)
+			} else {
+				assert.Equal(t, tt.header, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
+	"goods-manager/internal/transactor"
+	"slices"
+	"time"
+)
+
+type outboxRepository struct {
+	transactor *transactor.Transactor
+}
+
+// Create inserts pending message and sets its ID and creation timestamp
+func (o *outboxRepository) Create(ctx context.Context, message *entity.OutboxMessage) (err error) {
+	ctx, span := tracing.Start(ctx, "outboxRepository.Create")
+	defer tracing.End(span, &err)
+
+	header, err := json.Marshal(message.Header)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO outbox (subject, header, data)
+			VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	tx, db := o.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, message.Subject, header, message.Data)
+	} else {
+		row = db.QueryRowContext(ctx, query, message.Subject, header, message.Data)
+	}
+
+	return row.Scan(&message.Id, &message.CreatedAt)
+}
+
+// Claim postpones due pending messages for `lease` and returns them in order of creation.
+//
+// Locked rows are skipped, so instances don't claim the same messages.
+func (o *outboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) (_ []*entity.OutboxMessage, err error) {
+	ctx, span := tracing.Start(ctx, "outboxRepository.Claim")
+	defer tracing.End(span, &err)
+
+	query := `
+		UPDATE outbox SET next_attempt_at = NOW() + make_interval(secs => $2), attempts = attempts + 1
+			WHERE id IN (
+				SELECT id FROM outbox
+					WHERE sent_at IS NULL AND next_attempt_at <= NOW()
+					ORDER BY id
+					LIMIT $1
+					FOR UPDATE SKIP LOCKED
+			)
+		RETURNING id, subject, header, data, attempts, created_at
+	`
+
+	tx, db := o.transactor.Connection(ctx)
+	var rows *sql.Rows
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, limit, lease.Seconds())
+	} else {
+		rows, err = db.QueryContext(ctx, query, limit, lease.Seconds())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeRows(rows, &err)
+
+	messages := make([]*entity.OutboxMessage, 0)
+	for rows.Next() {
+		var message entity.OutboxMessage
+		var header []byte
+		if err := rows.Scan(&message.Id, &message.Subject, &header, &message.Data, &message.Attempts, &message.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(header, &message.Header); err != nil {
+			return nil, fmt.Errorf("failed unmarshal header of outbox message %d: %w", message.Id, err)
+		}
+
+		messages = append(messages, &message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING doesn't keep order of subquery
+	slices.SortFunc(messages, func(a, b *entity.OutboxMessage) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	return messages, nil
+}
+
+// MarkSent sets sending timestamp of the messages
+func (o *outboxRepository) MarkSent(ctx context.Context, ids []int64) (err error) {
+	ctx, span := tracing.Start(ctx, "outboxRepository.MarkSent")
+	defer tracing.End(span, &err)
+
+	query := `UPDATE outbox SET sent_at = NOW() WHERE id = ANY($1)`
+
+	tx, db := o.transactor.Connection(ctx)
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, pq.Array(ids))
+	} else {
+		_, err = db.ExecContext(ctx, query, pq.Array(ids))
+	}
+
+	return err
+}
+
+// DeleteSent deletes messages sent before `before` by batches, so table of long running outbox doesn't grow
+func (o *outboxRepository) DeleteSent(ctx context.Context, before time.Time, limit int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "outboxRepository.DeleteSent")
+	defer tracing.End(span, &err)
+
+	query := `
+		DELETE FROM outbox
+			WHERE id IN (
+				SELECT id FROM outbox
+					WHERE sent_at < $1
+					LIMIT $2
+			)
+	`
+
+	tx, db := o.transactor.Connection(ctx)
+	var result sql.Result
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, query, before, limit)
+	} else {
+		result, err = db.ExecContext(ctx, query, before, limit)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+func closeRows(rows *sql.Rows, err *error) {
+	if closeErr := rows.Close(); closeErr != nil {
+		*err = errors.Join(*err, fmt.Errorf("failed close rows: %w", closeErr))
+	}
+}
+
+func NewOutboxRepository(transactor *transactor.Transactor) domain.OutboxRepository {
+	return &outboxRepository{transactor: transactor}
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/transactor"
+	"testing"
+	"time"
+)
+
+func initTestRepository(t *testing.T) (*outboxRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	return &outboxRepository{transactor: transactor.NewTransactor(db)}, mock
+}
+
+func Test_outboxRepository_Create(t *testing.T) {
+	repo, mock := initTestRepository(t)
+
+	message := entity.OutboxMessage{Subject: "subject", Header: map[string][]string{"Event-Id": {"id"}}, Data: []byte(`{}`)}
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO outbox").
+		WithArgs("subject", []byte(`{"Event-Id":["id"]}`), []byte(`{}`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, "2024-03-05 12:00:00"))
+	mock.ExpectCommit()
+
+	// message is stored in transaction of the change
+	err := repo.transactor.WithTransaction(context.Background(), func(ctx context.Context) error {
+		return repo.Create(ctx, &message)
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, int64(7), message.Id)
+}
+
+func Test_outboxRepository_Claim(t *testing.T) {
+	repo, mock := initTestRepository(t)
+
+	mock.ExpectQuery("UPDATE outbox SET next_attempt_at .+ FOR UPDATE SKIP LOCKED").
+		WithArgs(10, float64(30)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "subject", "header", "data", "attempts", "created_at"}).
+			AddRow(2, "subject", []byte(`{}`), []byte(`2`), 1, "2024-03-05 12:00:00").
+			AddRow(1, "subject", []byte(`{"Event-Id":["id"]}`), []byte(`1`), 2, "2024-03-05 12:00:00"))
+
+	messages, err := repo.Claim(context.Background(), 10, 30*time.Second)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	require.Len(t, messages, 2)
+	assert.Equal(t, int64(1), messages[0].Id)
+	assert.Equal(t, map[string][]string{"Event-Id": {"id"}}, messages[0].Header)
+	assert.Equal(t, []byte(`1`), messages[0].Data)
+	assert.Equal(t, int64(2), messages[1].Id)
+}
+
+func Test_outboxRepository_MarkSent(t *testing.T) {
+	repo, mock := initTestRepository(t)
+
+	mock.ExpectExec("UPDATE outbox SET sent_at").
+		WithArgs(pq.Array([]int64{1, 2})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	require.NoError(t, repo.MarkSent(context.Background(), []int64{1, 2}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_outboxRepository_DeleteSent(t *testing.T) {
+	repo, mock := initTestRepository(t)
+
+	before := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+	mock.ExpectExec("DELETE FROM outbox .+ WHERE sent_at < \\$1").
+		WithArgs(before, 100).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	count, err := repo.DeleteSent(context.Background(), before, 100)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, 3, count)
+}
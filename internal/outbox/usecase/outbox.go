@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
+	"log/slog"
+	"time"
+)
+
+const (
+	// lease is time while claimed messages are not claimed again,
+	// so messages of crashed relay are published by another one
+	lease = 30 * time.Second
+
+	// flushTimeout is max time to wait for NATS server to receive or store published messages
+	flushTimeout = 5 * time.Second
+
+	// retention is time while sent messages are kept
+	retention = 24 * time.Hour
+)
+
+// outboxUsecase implementation `domain.OutboxUsecase`.
+//
+// Messages are stored in transaction of the change and published to NATS after commit,
+// so rolled back changes don't emit messages and failure of NATS doesn't abort changes.
+// Messages are published to JetStream if it is enabled, so they are marked after they are stored by stream.
+type outboxUsecase struct {
+	outboxRepo domain.OutboxRepository
+	publisher  publisher
+	logger     *slog.Logger
+}
+
+func (o *outboxUsecase) Enqueue(ctx context.Context, message *entity.OutboxMessage) (err error) {
+	ctx, span := tracing.Start(ctx, "outboxUsecase.Enqueue", trace.WithAttributes(attribute.String("messaging.destination.name", message.Subject)))
+	defer tracing.End(span, &err)
+
+	return o.outboxRepo.Create(ctx, message)
+}
+
+// Relay publishes claimed messages in order and marks published ones sent.
+//
+// Messages are marked after NATS server received them, messages which are not marked
+// because of failure are published again after lease.
+func (o *outboxUsecase) Relay(ctx context.Context, limit int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "outboxUsecase.Relay")
+	defer tracing.End(span, &err)
+
+	messages, err := o.outboxRepo.Claim(ctx, limit, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	span.SetAttributes(attribute.Int("messaging.batch.message_count", len(messages)))
+
+	published := make([]int64, 0, len(messages))
+	for _, message := range messages {
+		msg := &nats.Msg{Subject: message.Subject, Header: message.Header, Data: message.Data}
+		if err := o.publisher.Publish(ctx, msg); err != nil {
+			// the rest is published after lease to keep order
+			o.logger.ErrorContext(ctx, "failed publish outbox message", slog.Int64("id", message.Id), slog.Any("error", err))
+			break
+		}
+
+		published = append(published, message.Id)
+	}
+
+	if len(published) == 0 {
+		return len(messages), nil
+	}
+
+	if err := o.publisher.Flush(); err != nil {
+		return len(messages), err
+	}
+
+	return len(messages), o.outboxRepo.MarkSent(ctx, published)
+}
+
+// Cleanup deletes messages sent more than `retention` ago
+func (o *outboxUsecase) Cleanup(ctx context.Context, limit int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "outboxUsecase.Cleanup")
+	defer tracing.End(span, &err)
+
+	return o.outboxRepo.DeleteSent(ctx, time.Now().Add(-retention), limit)
+}
+
+// NewOutboxUsecase creates outbox which publishes to JetStream if `js` is not nil, otherwise to core NATS
+func NewOutboxUsecase(outboxRepo domain.OutboxRepository, nc *nats.Conn, js jetstream.JetStream, logger *slog.Logger) domain.OutboxUsecase {
+	var publisher publisher = &corePublisher{nc: nc}
+	if js != nil {
+		publisher = &jetStreamPublisher{js: js}
+	}
+
+	return &outboxUsecase{outboxRepo: outboxRepo, publisher: publisher, logger: logger}
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/mocks"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// publisherStub records published messages and fails publish of `fail` subject
+type publisherStub struct {
+	fail     string
+	flushErr error
+	calls    *[]string
+}
+
+func (p *publisherStub) Publish(_ context.Context, msg *nats.Msg) error {
+	if msg.Subject == p.fail {
+		return errors.New("publish failed")
+	}
+
+	*p.calls = append(*p.calls, "publish "+string(msg.Data))
+	return nil
+}
+
+func (p *publisherStub) Flush() error {
+	*p.calls = append(*p.calls, "flush")
+	return p.flushErr
+}
+
+func Test_outboxUsecase_Relay(t *testing.T) {
+	messages := []*entity.OutboxMessage{
+		{Id: 1, Subject: "ok", Data: []byte("1")},
+		{Id: 2, Subject: "ok", Data: []byte("2")},
+		{Id: 3, Subject: "fail", Data: []byte("3")},
+		{Id: 4, Subject: "ok", Data: []byte("4")},
+	}
+
+	tests := []struct {
+		name      string
+		messages  []*entity.OutboxMessage
+		flushErr  error
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			name:      "all published",
+			messages:  messages[:2],
+			wantCalls: []string{"publish 1", "publish 2", "flush", "mark [1 2]"},
+		},
+		{
+			// the rest is published after lease, so order is kept
+			name:      "stops at first failed publish",
+			messages:  messages,
+			wantCalls: []string{"publish 1", "publish 2", "flush", "mark [1 2]"},
+		},
+		{
+			name:      "nothing published",
+			messages:  messages[2:],
+			wantCalls: nil,
+		},
+		{
+			name:      "failed flush isn't marked",
+			messages:  messages[:2],
+			flushErr:  errors.New("flush failed"),
+			wantCalls: []string{"publish 1", "publish 2", "flush"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			outboxRepo := mocks.NewOutboxRepository(t)
+			outboxRepo.On("Claim", mock.Anything, 10, lease).Return(tt.messages, nil)
+			outboxRepo.On("MarkSent", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					calls = append(calls, "mark "+fmt.Sprint(args.Get(1)))
+				}).
+				Return(nil).Maybe()
+
+			usecase := &outboxUsecase{
+				outboxRepo: outboxRepo,
+				publisher:  &publisherStub{fail: "fail", flushErr: tt.flushErr, calls: &calls},
+				logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+			}
+
+			count, err := usecase.Relay(context.Background(), 10)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, len(tt.messages), count)
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func Test_outboxUsecase_Cleanup(t *testing.T) {
+	outboxRepo := mocks.NewOutboxRepository(t)
+	outboxRepo.On("DeleteSent", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= retention && time.Since(before) < retention+time.Minute
+	}), 100).Return(5, nil)
+
+	usecase := &outboxUsecase{outboxRepo: outboxRepo, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	count, err := usecase.Cleanup(context.Background(), 100)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+}
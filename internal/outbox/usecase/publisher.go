@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// publisher publishes messages of outbox to NATS
+type publisher interface {
+	// Publish publishes the message, it may be buffered until `Flush`
+	Publish(ctx context.Context, msg *nats.Msg) error
+
+	// Flush waits until NATS server receives published messages
+	Flush() error
+}
+
+// corePublisher publishes to core NATS, messages are buffered by connection
+type corePublisher struct {
+	nc *nats.Conn
+}
+
+func (c *corePublisher) Publish(_ context.Context, msg *nats.Msg) error {
+	return c.nc.PublishMsg(msg)
+}
+
+func (c *corePublisher) Flush() error {
+	return c.nc.FlushTimeout(flushTimeout)
+}
+
+// jetStreamPublisher publishes to JetStream and waits for ack of every message, so nothing is buffered
+type jetStreamPublisher struct {
+	js jetstream.JetStream
+}
+
+func (j *jetStreamPublisher) Publish(ctx context.Context, msg *nats.Msg) error {
+	ctx, cancel := context.WithTimeout(ctx, flushTimeout)
+	defer cancel()
+
+	_, err := j.js.PublishMsg(ctx, msg)
+	return err
+}
+
+func (j *jetStreamPublisher) Flush() error {
+	return nil
+}
@@ -0,0 +1,41 @@
+package workers
+
+import (
+	"context"
+	"goods-manager/internal/domain"
+	"log/slog"
+	"time"
+)
+
+// CleanupWorker deletes sent outbox messages after retention period
+type CleanupWorker struct {
+	outboxUsecase domain.OutboxUsecase
+	interval      time.Duration
+	batchSize     int
+	logger        *slog.Logger
+}
+
+// Run deletes batches of sent messages until context is done.
+// The next batch is deleted at once if batch is full, otherwise after `interval`.
+func (c *CleanupWorker) Run(ctx context.Context) {
+	for {
+		count, err := c.outboxUsecase.Cleanup(ctx, c.batchSize)
+		if err != nil {
+			c.logger.ErrorContext(ctx, "failed clean up outbox messages", slog.Any("error", err))
+		}
+
+		if err == nil && count == c.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.interval):
+		}
+	}
+}
+
+func NewCleanupWorker(outboxUsecase domain.OutboxUsecase, interval time.Duration, batchSize int, logger *slog.Logger) *CleanupWorker {
+	return &CleanupWorker{outboxUsecase: outboxUsecase, interval: interval, batchSize: batchSize, logger: logger}
+}
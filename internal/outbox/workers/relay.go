@@ -0,0 +1,41 @@
+package workers
+
+import (
+	"context"
+	"goods-manager/internal/domain"
+	"log/slog"
+	"time"
+)
+
+// RelayWorker publishes pending outbox messages
+type RelayWorker struct {
+	outboxUsecase domain.OutboxUsecase
+	interval      time.Duration
+	batchSize     int
+	logger        *slog.Logger
+}
+
+// Run relays batches of pending messages until context is done.
+// The next batch is relayed at once if batch is full, otherwise after `interval`.
+func (r *RelayWorker) Run(ctx context.Context) {
+	for {
+		count, err := r.outboxUsecase.Relay(ctx, r.batchSize)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "failed relay outbox messages", slog.Any("error", err))
+		}
+
+		if err == nil && count == r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+func NewRelayWorker(outboxUsecase domain.OutboxUsecase, interval time.Duration, batchSize int, logger *slog.Logger) *RelayWorker {
+	return &RelayWorker{outboxUsecase: outboxUsecase, interval: interval, batchSize: batchSize, logger: logger}
+}
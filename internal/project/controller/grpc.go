@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"context"
+	"github.com/gin-gonic/gin/binding"
+	goodsv1 "goods-manager/api/goods/v1"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/validation"
+)
+
+// ProjectGRPCController serves `goods.v1.ProjectsService`.
+//
+// Projects are created and deleted by admins of all projects,
+// other calls require a role in the project.
+type ProjectGRPCController struct {
+	goodsv1.UnimplementedProjectsServiceServer
+
+	projectUsecase domain.ProjectUsecase
+}
+
+func (p *ProjectGRPCController) CreateProject(ctx context.Context, req *goodsv1.CreateProjectRequest) (*goodsv1.Project, error) {
+	if err := domain.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validate(&ProjectRequest{Name: req.Name}); err != nil {
+		return nil, err
+	}
+
+	project := entity.Project{Name: req.Name}
+	if err := p.projectUsecase.Create(ctx, &project); err != nil {
+		return nil, err
+	}
+
+	return projectMessage(&project), nil
+}
+
+func (p *ProjectGRPCController) GetProject(ctx context.Context, req *goodsv1.GetProjectRequest) (*goodsv1.Project, error) {
+	if err := domain.Authorize(ctx, int(req.Id), entity.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	if err := validate(&ProjectPath{Id: int(req.Id)}); err != nil {
+		return nil, err
+	}
+
+	project, err := p.projectUsecase.Get(ctx, int(req.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	return projectMessage(project), nil
+}
+
+// ListProjects streams projects where principal has a role
+func (p *ProjectGRPCController) ListProjects(_ *goodsv1.ListProjectsRequest, stream goodsv1.ProjectsService_ListProjectsServer) error {
+	ctx := stream.Context()
+	principal := domain.PrincipalFromContext(ctx)
+	if principal == nil {
+		return domain.NewUnauthorizedError("unauthorized")
+	}
+
+	projects, err := p.projectUsecase.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, project := range projects {
+		if _, ok := principal.Role(project.Id); !ok {
+			continue
+		}
+
+		if err := stream.Send(projectMessage(project)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *ProjectGRPCController) UpdateProject(ctx context.Context, req *goodsv1.UpdateProjectRequest) (*goodsv1.Project, error) {
+	if err := domain.Authorize(ctx, int(req.Id), entity.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if err := validate(&ProjectPath{Id: int(req.Id)}, &ProjectRequest{Name: req.Name}); err != nil {
+		return nil, err
+	}
+
+	project := entity.Project{Id: int(req.Id), Name: req.Name}
+	if err := p.projectUsecase.Update(ctx, &project); err != nil {
+		return nil, err
+	}
+
+	return projectMessage(&project), nil
+}
+
+func (p *ProjectGRPCController) DeleteProject(ctx context.Context, req *goodsv1.DeleteProjectRequest) (*goodsv1.Project, error) {
+	if err := domain.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validate(&ProjectPath{Id: int(req.Id)}); err != nil {
+		return nil, err
+	}
+
+	project, err := p.projectUsecase.Get(ctx, int(req.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.projectUsecase.Delete(ctx, project.Id); err != nil {
+		return nil, err
+	}
+
+	return projectMessage(project), nil
+}
+
+// validate validates requests by rules of their `binding` tags
+func validate(objs ...any) error {
+	for _, obj := range objs {
+		if err := binding.Validator.ValidateStruct(obj); err != nil {
+			return validation.Error(err)
+		}
+	}
+
+	return nil
+}
+
+func projectMessage(project *entity.Project) *goodsv1.Project {
+	return &goodsv1.Project{
+		Id:        int32(project.Id),
+		Name:      project.Name,
+		CreatedAt: project.CreatedAt,
+	}
+}
+
+func NewProjectGRPCController(projectUsecase domain.ProjectUsecase) *ProjectGRPCController {
+	return &ProjectGRPCController{projectUsecase: projectUsecase}
+}
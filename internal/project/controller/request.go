@@ -0,0 +1,11 @@
+package controller
+
+// ProjectRequest is validated name of a project
+type ProjectRequest struct {
+	Name string `json:"name" binding:"required,notblank,max=255,singleline"`
+}
+
+// ProjectPath is validated ID of a project
+type ProjectPath struct {
+	Id int `json:"id" binding:"required,min=1"`
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
+	"goods-manager/internal/transactor"
+)
+
+type projectRepository struct {
+	transactor *transactor.Transactor
+}
+
+// Create inserts project and sets its ID and creation timestamp
+func (p *projectRepository) Create(ctx context.Context, project *entity.Project) (err error) {
+	ctx, span := tracing.Start(ctx, "projectRepository.Create")
+	defer tracing.End(span, &err)
+
+	query := `
+		INSERT INTO projects (name) VALUES ($1)
+		RETURNING id, created_at
+	`
+
+	tx, db := p.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, project.Name)
+	} else {
+		row = db.QueryRowContext(ctx, query, project.Name)
+	}
+
+	return row.Scan(&project.Id, &project.CreatedAt)
+}
+
+// Get gets project which is not removed
+func (p *projectRepository) Get(ctx context.Context, id int) (_ *entity.Project, err error) {
+	ctx, span := tracing.Start(ctx, "projectRepository.Get")
+	defer tracing.End(span, &err)
+
+	query := `
+		SELECT id, name, created_at FROM projects
+			WHERE id = $1 AND removed = false
+	`
+
+	tx, db := p.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, id)
+	} else {
+		row = db.QueryRowContext(ctx, query, id)
+	}
+
+	var project entity.Project
+	if err := row.Scan(&project.Id, &project.Name, &project.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrorProjectNotFound
+		}
+
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// List gets all projects which are not removed
+func (p *projectRepository) List(ctx context.Context) (_ []*entity.Project, err error) {
+	ctx, span := tracing.Start(ctx, "projectRepository.List")
+	defer tracing.End(span, &err)
+
+	query := `
+		SELECT id, name, created_at FROM projects
+			WHERE removed = false
+			ORDER BY id
+	`
+
+	tx, db := p.transactor.Connection(ctx)
+	var rows *sql.Rows
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query)
+	} else {
+		rows, err = db.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed close rows: %w", closeErr))
+		}
+	}()
+
+	projects := make([]*entity.Project, 0)
+	for rows.Next() {
+		var project entity.Project
+		if err := rows.Scan(&project.Id, &project.Name, &project.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, &project)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// Update updates name of the project
+func (p *projectRepository) Update(ctx context.Context, project *entity.Project) (err error) {
+	ctx, span := tracing.Start(ctx, "projectRepository.Update")
+	defer tracing.End(span, &err)
+
+	query := `
+		UPDATE projects SET name = $1 WHERE id = $2 AND removed = false
+		RETURNING created_at
+	`
+
+	tx, db := p.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, project.Name, project.Id)
+	} else {
+		row = db.QueryRowContext(ctx, query, project.Name, project.Id)
+	}
+
+	if err := row.Scan(&project.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrorProjectNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Delete marks the project as removed if all its goods are removed
+func (p *projectRepository) Delete(ctx context.Context, id int) (err error) {
+	ctx, span := tracing.Start(ctx, "projectRepository.Delete")
+	defer tracing.End(span, &err)
+
+	query := `
+		UPDATE projects SET removed = true
+			WHERE id = $1 AND removed = false
+				AND NOT EXISTS (SELECT 1 FROM goods WHERE project_id = $1 AND removed = false)
+	`
+
+	tx, db := p.transactor.Connection(ctx)
+	var result sql.Result
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, query, id)
+	} else {
+		result, err = db.ExecContext(ctx, query, id)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		// project is either not found or has goods
+		if _, err := p.Get(ctx, id); err != nil {
+			return err
+		}
+
+		return domain.ErrorProjectNotEmpty
+	}
+
+	return nil
+}
+
+func NewProjectRepository(transactor *transactor.Transactor) domain.ProjectRepository {
+	return &projectRepository{transactor: transactor}
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
+	"log/slog"
+	"strings"
+)
+
+// projectUsecase implementation `domain.ProjectUsecase`.
+type projectUsecase struct {
+	projectRepo domain.ProjectRepository
+	logger      *slog.Logger
+}
+
+// Create new project
+func (p *projectUsecase) Create(ctx context.Context, project *entity.Project) (err error) {
+	ctx, span := tracing.Start(ctx, "projectUsecase.Create")
+	defer tracing.End(span, &err)
+
+	if err := validateProject(project, false); err != nil {
+		return err
+	}
+
+	if err := p.projectRepo.Create(ctx, project); err != nil {
+		return err
+	}
+
+	p.logger.InfoContext(ctx, "project created", slog.Int("id", project.Id))
+	return nil
+}
+
+func (p *projectUsecase) Get(ctx context.Context, id int) (_ *entity.Project, err error) {
+	ctx, span := tracing.Start(ctx, "projectUsecase.Get")
+	defer tracing.End(span, &err)
+
+	return p.projectRepo.Get(ctx, id)
+}
+
+func (p *projectUsecase) List(ctx context.Context) (_ []*entity.Project, err error) {
+	ctx, span := tracing.Start(ctx, "projectUsecase.List")
+	defer tracing.End(span, &err)
+
+	return p.projectRepo.List(ctx)
+}
+
+// Update name of the project
+func (p *projectUsecase) Update(ctx context.Context, project *entity.Project) (err error) {
+	ctx, span := tracing.Start(ctx, "projectUsecase.Update")
+	defer tracing.End(span, &err)
+
+	if err := validateProject(project, true); err != nil {
+		return err
+	}
+
+	if err := p.projectRepo.Update(ctx, project); err != nil {
+		return err
+	}
+
+	p.logger.InfoContext(ctx, "project updated", slog.Int("id", project.Id))
+	return nil
+}
+
+// Delete project without goods
+func (p *projectUsecase) Delete(ctx context.Context, id int) (err error) {
+	ctx, span := tracing.Start(ctx, "projectUsecase.Delete")
+	defer tracing.End(span, &err)
+
+	if err := p.projectRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	p.logger.InfoContext(ctx, "project deleted", slog.Int("id", id))
+	return nil
+}
+
+// validateProject checks fields of the project, id is checked if `withId` is true
+func validateProject(project *entity.Project, withId bool) error {
+	var fields []domain.FieldError
+	if withId && project.Id < 1 {
+		fields = append(fields, domain.FieldError{Field: "id", Code: domain.FieldInvalid, Message: "id must be greater than 0"})
+	}
+	if strings.TrimSpace(project.Name) == "" {
+		fields = append(fields, domain.FieldError{Field: "name", Code: domain.FieldRequired, Message: "name is required"})
+	} else if len([]rune(project.Name)) > 255 {
+		fields = append(fields, domain.FieldError{Field: "name", Code: domain.FieldTooLong, Message: "name must be at most 255 characters"})
+	}
+
+	if len(fields) > 0 {
+		return domain.NewValidationError("invalid project", fields...)
+	}
+
+	return nil
+}
+
+func NewProjectUsecase(projectRepo domain.ProjectRepository, logger *slog.Logger) domain.ProjectUsecase {
+	return &projectUsecase{projectRepo: projectRepo, logger: logger}
+}
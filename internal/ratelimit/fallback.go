@@ -0,0 +1,28 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+)
+
+// fallbackLimiter implementation `Limiter` which uses `fallback` when `primary` fails,
+// e.g. when redis is unavailable.
+type fallbackLimiter struct {
+	primary  Limiter
+	fallback Limiter
+	logger   *slog.Logger
+}
+
+func (f *fallbackLimiter) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	result, err := f.primary.Allow(ctx, key, rule)
+	if err == nil {
+		return result, nil
+	}
+
+	f.logger.WarnContext(ctx, "rate limiter failed, fallback is used", slog.Any("error", err))
+	return f.fallback.Allow(ctx, key, rule)
+}
+
+func NewFallbackLimiter(primary, fallback Limiter, logger *slog.Logger) Limiter {
+	return &fallbackLimiter{primary: primary, fallback: fallback, logger: logger}
+}
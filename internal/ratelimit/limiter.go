@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule allows `Limit` requests per sliding `Window`
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Enabled reports whether rule limits requests
+func (r Rule) Enabled() bool {
+	return r.Limit > 0 && r.Window > 0
+}
+
+func (r Rule) String() string {
+	return strconv.Itoa(r.Limit) + "/" + r.Window.String()
+}
+
+// Result is a decision of limiter
+type Result struct {
+	Allowed bool
+
+	Limit     int
+	Remaining int
+
+	// ResetAfter is time until the window has room for full `Limit` again
+	ResetAfter time.Duration
+
+	// RetryAfter is time until next request will be allowed, zero if request is allowed
+	RetryAfter time.Duration
+}
+
+// Limiter counts requests of the key and decides whether the request is allowed
+type Limiter interface {
+	Allow(ctx context.Context, key string, rule Rule) (Result, error)
+}
+
+// ParseRule parses rule in format `<limit>/<window>`, e.g. `100/1m`
+func ParseRule(s string) (Rule, error) {
+	limit, window, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Rule{}, fmt.Errorf("invalid rate limit rule %q, expected <limit>/<window>", s)
+	}
+
+	limitInt, err := strconv.Atoi(limit)
+	if err != nil || limitInt < 1 {
+		return Rule{}, fmt.Errorf("invalid limit of rule %q", s)
+	}
+
+	windowDuration, err := time.ParseDuration(window)
+	if err != nil || windowDuration <= 0 {
+		return Rule{}, fmt.Errorf("invalid window of rule %q", s)
+	}
+
+	return Rule{Limit: limitInt, Window: windowDuration}, nil
+}
+
+// ParseRoutes parses comma separated rules of routes in format `<METHOD> <route>=<rule>`,
+// e.g. `PATCH /good/reprioritiize=10/1m,POST /good/create=30/1m`
+func ParseRoutes(s string) (map[string]Rule, error) {
+	routes := make(map[string]Rule)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		route, rule, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid route rate limit %q, expected <METHOD> <route>=<rule>", item)
+		}
+
+		method, path, ok := strings.Cut(strings.TrimSpace(route), " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid route %q, expected <METHOD> <route>", route)
+		}
+
+		parsed, err := ParseRule(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		routes[RouteKey(method, strings.TrimSpace(path))] = parsed
+	}
+
+	return routes, nil
+}
+
+// RouteKey returns key of route in rules of routes
+func RouteKey(method, route string) string {
+	return strings.ToUpper(method) + " " + route
+}
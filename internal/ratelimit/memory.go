@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepEvery is count of calls between removals of expired windows
+const sweepEvery = 1024
+
+// window is a log of requests sorted by time
+type window struct {
+	requests []time.Time
+	size     time.Duration
+}
+
+// memoryLimiter implementation `Limiter` using sliding window log in process memory.
+//
+// Counters are not shared between instances, so it is used as fallback only.
+type memoryLimiter struct {
+	mx      sync.Mutex
+	windows map[string]*window
+	calls   int
+	now     func() time.Time
+}
+
+func (m *memoryLimiter) Allow(_ context.Context, key string, rule Rule) (Result, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	now := m.now()
+	m.calls++
+	if m.calls%sweepEvery == 0 {
+		m.sweep(now)
+	}
+
+	w, ok := m.windows[key]
+	if !ok {
+		w = &window{}
+		m.windows[key] = w
+	}
+	w.size = rule.Window
+	w.expire(now)
+
+	result := Result{Limit: rule.Limit}
+	if len(w.requests) < rule.Limit {
+		w.requests = append(w.requests, now)
+		result.Allowed = true
+	} else {
+		result.RetryAfter = w.requests[0].Add(rule.Window).Sub(now)
+	}
+
+	result.Remaining = rule.Limit - len(w.requests)
+	result.ResetAfter = w.requests[len(w.requests)-1].Add(rule.Window).Sub(now)
+
+	return result, nil
+}
+
+// sweep removes windows without requests
+func (m *memoryLimiter) sweep(now time.Time) {
+	for key, w := range m.windows {
+		w.expire(now)
+		if len(w.requests) == 0 {
+			delete(m.windows, key)
+		}
+	}
+}
+
+// expire removes requests which are out of the window
+func (w *window) expire(now time.Time) {
+	since := now.Add(-w.size)
+
+	i := 0
+	for i < len(w.requests) && !w.requests[i].After(since) {
+		i++
+	}
+
+	w.requests = w.requests[i:]
+}
+
+func NewMemoryLimiter() Limiter {
+	return &memoryLimiter{windows: make(map[string]*window), now: time.Now}
+}
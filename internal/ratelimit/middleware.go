@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"github.com/gin-gonic/gin"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/httperror"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Config is configuration of rate limits.
+// Route without own rule uses `Default`, disabled rule means no limit.
+type Config struct {
+	Default Rule
+
+	// Routes maps `RouteKey` of route to its rule
+	Routes map[string]Rule
+
+	// IP is rule of all requests of client IP, it is checked before authentication
+	IP Rule
+}
+
+// rule returns rule of the route and key of its window
+func (c Config) rule(method, route string) (Rule, string) {
+	key := RouteKey(method, route)
+	if rule, ok := c.Routes[key]; ok {
+		return rule, key
+	}
+
+	// all routes share default window
+	return c.Default, "default"
+}
+
+// Middleware limits requests of every client per route.
+//
+// Client is principal subject if request is authenticated, otherwise client IP.
+// Rate limit headers are set to every limited response, rejected request gets 429 with `Retry-After`.
+// Request is allowed if limiter fails.
+func Middleware(limiter Limiter, config Config, logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rule, routeKey := config.rule(c.Request.Method, c.FullPath())
+		if !rule.Enabled() {
+			c.Next()
+			return
+		}
+
+		client := "ip:" + c.ClientIP()
+		if principal := domain.PrincipalFromContext(c.Request.Context()); principal != nil {
+			client = principal.Subject
+		}
+
+		limit(c, limiter, routeKey+":"+client, rule, logger)
+	}
+}
+
+// IPMiddleware limits all requests of every client IP by `config.IP`.
+//
+// It is used before authentication, so requests with invalid or missing credentials are limited too
+// and keys or tokens can't be brute forced.
+func IPMiddleware(limiter Limiter, config Config, logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !config.IP.Enabled() {
+			c.Next()
+			return
+		}
+
+		limit(c, limiter, "ip:"+c.ClientIP(), config.IP, logger)
+	}
+}
+
+// limit counts request in window of the key and aborts request if limit is exceeded
+func limit(c *gin.Context, limiter Limiter, key string, rule Rule, logger *slog.Logger) {
+	result, err := limiter.Allow(c.Request.Context(), key, rule)
+	if err != nil {
+		logger.ErrorContext(c.Request.Context(), "failed check rate limit", slog.Any("error", err))
+		c.Next()
+		return
+	}
+
+	c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("X-RateLimit-Reset", strconv.Itoa(seconds(result.ResetAfter)))
+
+	if !result.Allowed {
+		c.Header("Retry-After", strconv.Itoa(seconds(result.RetryAfter)))
+		httperror.Abort(c, domain.NewRateLimitedError("rate limit exceeded"))
+		return
+	}
+
+	c.Next()
+}
+
+// seconds rounds duration up to whole seconds
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,156 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/httperror"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule("100/1m")
+	require.NoError(t, err)
+	assert.Equal(t, Rule{Limit: 100, Window: time.Minute}, rule)
+
+	for _, s := range []string{"", "100", "0/1m", "-1/1m", "a/1m", "10/x", "10/0s"} {
+		_, err := ParseRule(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseRoutes(t *testing.T) {
+	routes, err := ParseRoutes("patch /good/reprioritiize=10/1m, POST /good/create=30/1s")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Rule{
+		"PATCH /good/reprioritiize": {Limit: 10, Window: time.Minute},
+		"POST /good/create":         {Limit: 30, Window: time.Second},
+	}, routes)
+
+	routes, err = ParseRoutes("")
+	require.NoError(t, err)
+	assert.Empty(t, routes)
+
+	_, err = ParseRoutes("/good/create=10/1m")
+	assert.Error(t, err)
+}
+
+func Test_memoryLimiter_Allow(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := NewMemoryLimiter().(*memoryLimiter)
+	limiter.now = func() time.Time { return now }
+
+	rule := Rule{Limit: 2, Window: time.Minute}
+
+	result, _ := limiter.Allow(context.Background(), "key", rule)
+	assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 1, ResetAfter: time.Minute}, result)
+
+	now = now.Add(10 * time.Second)
+	result, _ = limiter.Allow(context.Background(), "key", rule)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	now = now.Add(10 * time.Second)
+	result, _ = limiter.Allow(context.Background(), "key", rule)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 40*time.Second, result.RetryAfter)
+
+	// other keys have own windows
+	result, _ = limiter.Allow(context.Background(), "other", rule)
+	assert.True(t, result.Allowed)
+
+	// first request leaves the window
+	now = now.Add(40 * time.Second)
+	result, _ = limiter.Allow(context.Background(), "key", rule)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+}
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string, Rule) (Result, error) {
+	return Result{}, errors.New("connection refused")
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	config := Config{
+		Default: Rule{Limit: 5, Window: time.Minute},
+		Routes:  map[string]Rule{"POST /good/create": {Limit: 1, Window: time.Minute}},
+	}
+	limiter := NewFallbackLimiter(failingLimiter{}, NewMemoryLimiter(), logger)
+
+	r := gin.New()
+	r.Use(httperror.Middleware(logger))
+	r.Use(Middleware(limiter, config, logger))
+	r.POST("/good/create", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/good/list", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := func(method, path, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = ip + ":1234"
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request(http.MethodPost, "/good/create", "10.0.0.1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("X-RateLimit-Reset"))
+
+	w = request(http.MethodPost, "/good/create", "10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	// other client and route are not affected
+	w = request(http.MethodPost, "/good/create", "10.0.0.2")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = request(http.MethodGet, "/good/list", "10.0.0.1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "5", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "4", w.Header().Get("X-RateLimit-Remaining"))
+}
+
+func TestIPMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	config := Config{IP: Rule{Limit: 2, Window: time.Minute}}
+	limiter := NewMemoryLimiter()
+
+	// authentication rejects every request after IP limit
+	r := gin.New()
+	r.Use(httperror.Middleware(logger))
+	r.Use(IPMiddleware(limiter, config, logger))
+	r.Use(func(c *gin.Context) { httperror.Abort(c, domain.NewUnauthorizedError("invalid API key")) })
+	r.GET("/good/list", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := func(ip string) int {
+		req := httptest.NewRequest(http.MethodGet, "/good/list", nil)
+		req.Header.Set("X-API-Key", "guess")
+		req.RemoteAddr = ip + ":1234"
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, request("10.0.0.1"))
+	assert.Equal(t, http.StatusUnauthorized, request("10.0.0.1"))
+	assert.Equal(t, http.StatusTooManyRequests, request("10.0.0.1"))
+
+	// other client IP is not affected
+	assert.Equal(t, http.StatusUnauthorized, request("10.0.0.2"))
+}
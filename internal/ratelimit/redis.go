@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"goods-manager/internal/tracing"
+	"time"
+)
+
+// slidingWindow is a sliding window log stored in sorted set.
+// Scores are microseconds of requests, time of redis server is used to keep instances consistent.
+//
+// KEYS[1] - key of the sorted set
+// ARGV[1] - limit
+// ARGV[2] - window in microseconds
+//
+// Returns {allowed, remaining, reset after (us), retry after (us)}
+var slidingWindow = redis.NewScript(`
+local key = KEYS[1]
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', key, now, now .. '-' .. math.random(1000000))
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', key, math.ceil(window / 1000))
+
+local oldest = tonumber(redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')[2])
+local newest = tonumber(redis.call('ZRANGE', key, -1, -1, 'WITHSCORES')[2])
+
+local retry = 0
+if allowed == 0 then
+	retry = oldest + window - now
+end
+
+return {allowed, limit - count, newest + window - now, retry}
+`)
+
+// redisLimiter implementation `Limiter` using sliding window log in redis
+type redisLimiter struct {
+	client *redis.Client
+	prefix string
+}
+
+func (r *redisLimiter) Allow(ctx context.Context, key string, rule Rule) (_ Result, err error) {
+	ctx, span := tracing.Start(ctx, "redisLimiter.Allow")
+	defer tracing.End(span, &err)
+
+	res, err := slidingWindow.Run(ctx, r.client, []string{r.prefix + key}, rule.Limit, rule.Window.Microseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	if len(res) != 4 {
+		return Result{}, fmt.Errorf("unexpected result of rate limit script: %v", res)
+	}
+
+	return Result{
+		Allowed:    res[0] == 1,
+		Limit:      rule.Limit,
+		Remaining:  int(res[1]),
+		ResetAfter: time.Duration(res[2]) * time.Microsecond,
+		RetryAfter: time.Duration(res[3]) * time.Microsecond,
+	}, nil
+}
+
+// NewRedisLimiter creates limiter storing windows in redis with keys prefixed by `prefix`
+func NewRedisLimiter(client *redis.Client, prefix string) Limiter {
+	return &redisLimiter{client: client, prefix: prefix}
+}
@@ -0,0 +1,107 @@
+package stream
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"goods-manager/internal/httperror"
+	"goods-manager/internal/validation"
+	"time"
+)
+
+const (
+	// LastEventIdHeader is header with ID of the last received event, it is sent by `EventSource` on reconnect
+	LastEventIdHeader = "Last-Event-ID"
+
+	// retry is reconnection delay of client in milliseconds
+	retry = 3000
+)
+
+type projectPath struct {
+	ProjectId int `uri:"projectId" binding:"required,min=1"`
+}
+
+// Handler streams changes of goods of the project as Server-Sent Events.
+//
+// Every change is `good` event with envelope of the event (`entity.Event`) as data and event ID as `id`.
+// Client resumes after `Last-Event-ID` header or `lastEventId` query parameter,
+// `reset` event is sent first if the event is not in buffer anymore.
+// Comment is sent every `heartbeat` to keep idle connection open.
+//
+// @Summary		Stream changes of goods of the project
+// @Description	Server-Sent Events: `good` event with envelope of the event as data for every change, `reset` event if changes after `Last-Event-ID` are lost and goods must be reloaded.
+// @Tags		goods v2
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		text/event-stream
+//
+// @Param		projectId		path		int			true	"Project ID"	minimum(1)
+// @Param		Last-Event-ID	header		string		false	"ID of the last received event to resume after"
+// @Param		lastEventId		query		string		false	"ID of the last received event, used if header is not set"
+//
+// @Success		200		{string}	string				"Stream of events"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Router		/v2/projects/{projectId}/goods/events		[get]
+func Handler(hub *Hub, heartbeat time.Duration) gin.HandlerFunc {
+	if heartbeat <= 0 {
+		heartbeat = DefaultHeartbeat
+	}
+
+	return func(c *gin.Context) {
+		var path projectPath
+		if err := c.ShouldBindUri(&path); err != nil {
+			httperror.Abort(c, validation.Error(err))
+			return
+		}
+
+		lastEventId := c.GetHeader(LastEventIdHeader)
+		if lastEventId == "" {
+			lastEventId = c.Query("lastEventId")
+		}
+
+		replay, events, reset, cancel := hub.Subscribe(path.ProjectId, lastEventId)
+		defer cancel()
+
+		header := c.Writer.Header()
+		header.Set("Content-Type", "text/event-stream")
+		header.Set("Cache-Control", "no-cache")
+		header.Set("Connection", "keep-alive")
+		// disable buffering of reverse proxy
+		header.Set("X-Accel-Buffering", "no")
+		c.Status(200)
+
+		fmt.Fprintf(c.Writer, "retry: %d\n\n", retry)
+		if reset {
+			fmt.Fprint(c.Writer, "event: reset\ndata: {}\n\n")
+		}
+		for _, event := range replay {
+			writeEvent(c, event)
+		}
+		c.Writer.Flush()
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.Request.Context().Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				writeEvent(c, event)
+			case <-ticker.C:
+				fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// writeEvent writes the event in Server-Sent Events format, data is single line JSON
+func writeEvent(c *gin.Context, event Event) {
+	fmt.Fprintf(c.Writer, "id: %s\nevent: good\ndata: %s\n\n", event.Id, event.Data)
+}
@@ -0,0 +1,159 @@
+package stream
+
+import (
+	"bufio"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/logger/usecase"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestHub(bufferSize int) *Hub {
+	return NewHub(nil, Config{BufferSize: bufferSize}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// publish passes change of good of the project to hub as it is received from NATS
+func publish(hub *Hub, projectId int, data string) string {
+	id := uuid.Must(uuid.NewV7()).String()
+	publishId(hub, id, projectId, data)
+
+	return id
+}
+
+// publishId passes change with the ID, so changes are received not in order of IDs
+func publishId(hub *Hub, id string, projectId int, data string) {
+	msg := nats.NewMsg(usecase.Subject)
+	msg.Header.Set(usecase.EventIdHeader, id)
+	msg.Data = []byte(`{"project_id":` + strconv.Itoa(projectId) + `,` + data + `}`)
+	hub.receive(msg)
+}
+
+// readStream reads lines of response until `count` blank lines, i.e. ends of events, are read
+func readStream(t *testing.T, server *httptest.Server, header http.Header, count int) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v2/projects/1/goods/events", nil)
+	require.NoError(t, err)
+	req.Header = header
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	var out strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for count > 0 && scanner.Scan() {
+		out.WriteString(scanner.Text() + "\n")
+		if scanner.Text() == "" {
+			count--
+		}
+	}
+
+	return out.String()
+}
+
+func newTestServer(hub *Hub, heartbeat time.Duration) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/v2/projects/:projectId/goods/events", Handler(hub, heartbeat))
+
+	return httptest.NewServer(r)
+}
+
+func TestHandler_Resume(t *testing.T) {
+	hub := newTestHub(10)
+	first := publish(hub, 1, `"id":1`)
+	second := publish(hub, 1, `"id":2`)
+	publish(hub, 2, `"id":3`)
+
+	server := newTestServer(hub, time.Minute)
+	defer server.Close()
+
+	out := readStream(t, server, http.Header{LastEventIdHeader: {first}}, 2)
+
+	assert.Equal(t, "retry: 3000\n\nid: "+second+"\nevent: good\ndata: {\"project_id\":1,\"id\":2}\n\n", out)
+}
+
+func TestHandler_ResumeOutOfOrder(t *testing.T) {
+	// transaction of the earlier ID commits later, so its change is received after the later one
+	earlier := uuid.Must(uuid.NewV7()).String()
+	later := uuid.Must(uuid.NewV7()).String()
+
+	hub := newTestHub(10)
+	publishId(hub, later, 1, `"id":1`)
+	publishId(hub, earlier, 1, `"id":2`)
+
+	server := newTestServer(hub, time.Minute)
+	defer server.Close()
+
+	out := readStream(t, server, http.Header{LastEventIdHeader: {later}}, 2)
+
+	assert.Equal(t, "retry: 3000\n\nid: "+earlier+"\nevent: good\ndata: {\"project_id\":1,\"id\":2}\n\n", out)
+}
+
+func TestHandler_Reset(t *testing.T) {
+	hub := newTestHub(1)
+	first := publish(hub, 1, `"id":1`)
+	publish(hub, 1, `"id":2`)
+	publish(hub, 1, `"id":3`)
+
+	server := newTestServer(hub, time.Minute)
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		lastEventId string
+	}{
+		{name: "evicted", lastEventId: first},
+		{name: "invalid", lastEventId: "1"},
+		{name: "before start", lastEventId: "018e0e3a-5b2c-7000-8000-000000000000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := readStream(t, server, http.Header{LastEventIdHeader: {tt.lastEventId}}, 2)
+
+			// buffered events are not replayed, client reloads goods
+			assert.Equal(t, "retry: 3000\n\nevent: reset\ndata: {}\n\n", out)
+		})
+	}
+}
+
+func TestHandler_LiveAndHeartbeat(t *testing.T) {
+	hub := newTestHub(10)
+	server := newTestServer(hub, 50*time.Millisecond)
+	defer server.Close()
+
+	done := make(chan string)
+	go func() {
+		done <- readStream(t, server, http.Header{}, 3)
+	}()
+
+	// wait for subscriber
+	require.Eventually(t, func() bool {
+		hub.mx.Lock()
+		defer hub.mx.Unlock()
+		return len(hub.project(1).subscribers) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	id := publish(hub, 1, `"id":1`)
+
+	out := <-done
+	assert.Contains(t, out, "id: "+id+"\nevent: good\ndata: {\"project_id\":1,\"id\":1}\n\n")
+	assert.Contains(t, out, ": heartbeat\n\n")
+}
@@ -0,0 +1,196 @@
+package stream
+
+import (
+	"encoding/json"
+	"github.com/nats-io/nats.go"
+	"goods-manager/internal/logger/usecase"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHeartbeat is default interval of heartbeat comments
+	DefaultHeartbeat = 15 * time.Second
+
+	// DefaultBufferSize is default count of the latest events of project kept for resume
+	DefaultBufferSize = 1000
+
+	// subscriberBuffer is count of events queued for subscriber,
+	// subscriber which is not keeping up is disconnected
+	subscriberBuffer = 64
+)
+
+// Config is configuration of change stream, zero values are defaults
+type Config struct {
+	// Heartbeat is interval of comments sent to keep idle connection open
+	Heartbeat time.Duration
+
+	// BufferSize is count of the latest events of project kept for resume by `Last-Event-ID`
+	BufferSize int
+}
+
+// Event is change of good received from `usecase.Subject`
+type Event struct {
+	// Id is ID of the message, it is the same on all instances.
+	// IDs are generated before commit and relayed after it, so they are not ordered by receiving.
+	Id        string
+	ProjectId int
+	Data      []byte
+}
+
+// Hub receives changes of goods from NATS and fans them out to subscribers of the project.
+//
+// Every instance subscribes to `usecase.Subject` without queue group,
+// so clients of any instance get all events with the same IDs and may resume on another instance.
+type Hub struct {
+	nc         *nats.Conn
+	bufferSize int
+	logger     *slog.Logger
+
+	mx       sync.Mutex
+	projects map[int]*project
+	sub      *nats.Subscription
+}
+
+// project is buffer of the latest events in order of receiving and subscribers of the project
+type project struct {
+	events      []Event
+	subscribers map[chan Event]struct{}
+}
+
+// Run starts receiving of `usecase.Subject`
+func (h *Hub) Run() error {
+	sub, err := h.nc.Subscribe(usecase.Subject, h.receive)
+	if err != nil {
+		return err
+	}
+
+	h.mx.Lock()
+	h.sub = sub
+	h.mx.Unlock()
+
+	h.logger.Info("change stream is subscribed", slog.String("subject", usecase.Subject))
+	return nil
+}
+
+// Close stops receiving and disconnects all subscribers
+func (h *Hub) Close() error {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	for _, p := range h.projects {
+		for events := range p.subscribers {
+			close(events)
+		}
+		p.subscribers = map[chan Event]struct{}{}
+	}
+
+	if h.sub == nil {
+		return nil
+	}
+
+	return h.sub.Unsubscribe()
+}
+
+// Subscribe subscribes to events of the project after `lastEventId`.
+//
+// It returns events received after `lastEventId` and channel of new events, which is closed
+// if subscriber is too slow or hub is closed. `reset` is true if `lastEventId` is not in buffer,
+// so events after it are not known and client has to reload state. Empty `lastEventId` subscribes to new events only.
+// `cancel` must be called when subscriber stops reading.
+func (h *Hub) Subscribe(projectId int, lastEventId string) (replay []Event, events <-chan Event, reset bool, cancel func()) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	p := h.project(projectId)
+
+	if lastEventId != "" {
+		// events are replayed by position, because event with lower ID may be received later
+		position := slices.IndexFunc(p.events, func(event Event) bool { return event.Id == lastEventId })
+		reset = position < 0
+		if !reset {
+			replay = slices.Clone(p.events[position+1:])
+		}
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	p.subscribers[ch] = struct{}{}
+
+	cancel = func() {
+		h.mx.Lock()
+		defer h.mx.Unlock()
+
+		if _, ok := p.subscribers[ch]; ok {
+			delete(p.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return replay, ch, reset, cancel
+}
+
+// receive buffers event of the message and sends it to subscribers of the project
+func (h *Hub) receive(m *nats.Msg) {
+	id := m.Header.Get(usecase.EventIdHeader)
+	if id == "" {
+		h.logger.Warn("change without event ID is skipped", slog.String("subject", m.Subject))
+		return
+	}
+
+	var good struct {
+		ProjectId int `json:"project_id"`
+	}
+	if err := json.Unmarshal(m.Data, &good); err != nil {
+		h.logger.Error("failed unmarshal change from nats", slog.Any("error", err))
+		return
+	}
+
+	event := Event{Id: id, ProjectId: good.ProjectId, Data: m.Data}
+
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	p := h.project(event.ProjectId)
+	p.events = append(p.events, event)
+	if len(p.events) > h.bufferSize {
+		p.events = append(p.events[:0], p.events[len(p.events)-h.bufferSize:]...)
+	}
+
+	for ch := range p.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// client resumes by Last-Event-ID after reconnect
+			delete(p.subscribers, ch)
+			close(ch)
+			h.logger.Warn("slow change stream subscriber is disconnected", slog.Int("project_id", event.ProjectId))
+		}
+	}
+}
+
+// project returns state of the project, it is created if not exists
+func (h *Hub) project(projectId int) *project {
+	p, ok := h.projects[projectId]
+	if !ok {
+		p = &project{subscribers: map[chan Event]struct{}{}}
+		h.projects[projectId] = p
+	}
+
+	return p
+}
+
+func NewHub(nc *nats.Conn, config Config, logger *slog.Logger) *Hub {
+	bufferSize := config.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	return &Hub{
+		nc:         nc,
+		bufferSize: bufferSize,
+		logger:     logger,
+		projects:   map[int]*project{},
+	}
+}
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer used by all layers of the application
+const InstrumentationName = "goods-manager"
+
+// Start creates a span with `name` as a child of the span stored in `ctx`.
+//
+// Example:
+//
+//	func (g *goodRepository) Get(ctx context.Context, id int) (_ *entity.Good, err error) {
+//	    ctx, span := tracing.Start(ctx, "goodRepository.Get")
+//	    defer tracing.End(span, &err)
+//	    ...
+//	}
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name, opts...)
+}
+
+// End ends the span and records the error pointed by `err` if it is not nil
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+
+	span.End()
+}
+
+// InjectNats injects trace context from `ctx` into headers of the nats message
+func InjectNats(ctx context.Context, msg *nats.Msg) {
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(msg.Header))
+}
+
+// ExtractNats returns context with trace context extracted from headers of the nats message
+func ExtractNats(ctx context.Context, msg *nats.Msg) context.Context {
+	if msg.Header == nil {
+		return ctx
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(msg.Header))
+}
@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func initTestTracer(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		_ = provider.Shutdown(context.Background())
+	})
+
+	return recorder
+}
+
+func TestInjectExtractNats(t *testing.T) {
+	initTestTracer(t)
+
+	ctx, span := Start(context.Background(), "producer")
+	defer span.End()
+
+	msg := nats.NewMsg("subject")
+	InjectNats(ctx, msg)
+
+	assert.NotEmpty(t, propagation.HeaderCarrier(msg.Header).Get("traceparent"))
+
+	extracted := trace.SpanContextFromContext(ExtractNats(context.Background(), msg))
+	assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), extracted.SpanID())
+}
+
+func TestExtractNats_WithoutHeader(t *testing.T) {
+	ctx := ExtractNats(context.Background(), &nats.Msg{Subject: "subject"})
+
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
+
+func TestEnd_RecordsError(t *testing.T) {
+	recorder := initTestTracer(t)
+
+	_, span := Start(context.Background(), "failed")
+	err := errors.New("failed")
+	End(span, &err)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Len(t, spans[0].Events(), 1)
+	}
+}
@@ -3,10 +3,13 @@ package transactor
 import (
 	"context"
 	"database/sql"
+	"errors"
 )
 
 type txKey struct{}
 
+type afterCommitKey struct{}
+
 // Transactor represents a type that provides transaction management for database operations.
 type Transactor struct {
 	db *sql.DB
@@ -34,13 +37,17 @@ func (t *Transactor) Connection(ctx context.Context) (*sql.Tx, *sql.DB) {
 //	if err != nil {
 //	    // Handle the error
 //	}
+//
+// Functions registered by `AfterCommit` are run after commit, their errors are returned although changes are committed.
 func (t *Transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
 	tx, err := t.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
 		return err
 	}
 
-	if err := fn(t.injectTx(ctx, tx)); err != nil {
+	var hooks []func(ctx context.Context) error
+	txCtx := context.WithValue(t.injectTx(ctx, tx), afterCommitKey{}, &hooks)
+	if err := fn(txCtx); err != nil {
 		if err := tx.Rollback(); err != nil {
 			return err
 		}
@@ -48,7 +55,30 @@ func (t *Transactor) WithTransaction(ctx context.Context, fn func(ctx context.Co
 		return err
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, hook := range hooks {
+		errs = append(errs, hook(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// AfterCommit runs `fn` after commit of transaction of the context, `fn` isn't run if transaction is rolled back.
+// It runs `fn` at once without transaction.
+//
+// It is used for side effects which must not see uncommitted changes, e.g. writes to cache.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context) error) error {
+	hooks, ok := ctx.Value(afterCommitKey{}).(*[]func(ctx context.Context) error)
+	if !ok || extractTx(ctx) == nil {
+		return fn(ctx)
+	}
+
+	*hooks = append(*hooks, fn)
+	return nil
 }
 
 // injectTx create new transaction and injects it into context
@@ -0,0 +1,170 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/httperror"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Custom tags of validation rules
+const (
+	// TagNotBlank requires string with non-space characters
+	TagNotBlank = "notblank"
+
+	// TagSingleLine allows printable characters only
+	TagSingleLine = "singleline"
+
+	// TagMultiLine allows printable characters and line breaks
+	TagMultiLine = "multiline"
+
+	// TagHTTPURL requires absolute URL with `http` or `https` scheme
+	TagHTTPURL = "httpurl"
+)
+
+// Register registers custom rules in validator of gin binding
+// and makes field errors use names of fields from `json`, `form` and `uri` tags.
+func Register() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("validator of binding is not go-playground validator")
+	}
+
+	v.RegisterTagNameFunc(fieldName)
+
+	rules := map[string]validator.Func{
+		TagNotBlank:   notBlank,
+		TagSingleLine: singleLine,
+		TagMultiLine:  multiLine,
+		TagHTTPURL:    httpURL,
+	}
+	for tag, rule := range rules {
+		if err := v.RegisterValidation(tag, rule); err != nil {
+			return fmt.Errorf("failed register rule %s: %w", tag, err)
+		}
+	}
+
+	return nil
+}
+
+// Error converts error of binding to validation error of domain with details of fields
+func Error(err error) error {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		fields := make([]domain.FieldError, 0, len(validationErrors))
+		for _, fieldError := range validationErrors {
+			fields = append(fields, toFieldError(fieldError))
+		}
+
+		return domain.NewValidationError("invalid input", fields...)
+	}
+
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) {
+		return domain.NewFieldError(typeError.Field, domain.FieldInvalid, typeError.Field+" must be "+typeError.Type.String())
+	}
+
+	if errors.Is(err, io.EOF) {
+		return domain.NewValidationError("body is required")
+	}
+
+	return domain.NewValidationError("invalid input: " + err.Error())
+}
+
+// Bind binds request to `obj` by `bind` and aborts request with validation error if it fails
+func Bind(c *gin.Context, obj any, bind func(any) error) bool {
+	if err := bind(obj); err != nil {
+		httperror.Abort(c, Error(err))
+		return false
+	}
+
+	return true
+}
+
+// toFieldError describes failed rule of the field
+func toFieldError(fe validator.FieldError) domain.FieldError {
+	field := fe.Field()
+	isString := fe.Kind() == reflect.String
+	isCollection := fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map
+
+	code, message := domain.FieldInvalid, field+" is invalid"
+	switch fe.Tag() {
+	case "required":
+		code, message = domain.FieldRequired, field+" is required"
+	case TagNotBlank:
+		code, message = domain.FieldRequired, field+" must not be blank"
+	case TagSingleLine:
+		code, message = domain.FieldInvalidChars, field+" must contain printable characters only"
+	case TagMultiLine:
+		code, message = domain.FieldInvalidChars, field+" must contain printable characters and line breaks only"
+	case "max", "lte":
+		switch {
+		case isString:
+			code, message = domain.FieldTooLong, field+" must be at most "+fe.Param()+" characters"
+		case isCollection:
+			code, message = domain.FieldTooLong, field+" must contain at most "+fe.Param()+" items"
+		default:
+			code, message = domain.FieldOutOfRange, field+" must be at most "+fe.Param()
+		}
+	case "min", "gte":
+		switch {
+		case isString:
+			code, message = domain.FieldTooShort, field+" must be at least "+fe.Param()+" characters"
+		case isCollection:
+			code, message = domain.FieldTooShort, field+" must contain at least "+fe.Param()+" items"
+		default:
+			code, message = domain.FieldOutOfRange, field+" must be at least "+fe.Param()
+		}
+	case TagHTTPURL:
+		message = field + " must be absolute http or https URL"
+	case "oneof":
+		message = field + " must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	}
+
+	return domain.FieldError{Field: field, Code: code, Message: message}
+}
+
+// fieldName returns name of the field in request
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+func notBlank(fl validator.FieldLevel) bool {
+	return strings.TrimSpace(fl.Field().String()) != ""
+}
+
+func singleLine(fl validator.FieldLevel) bool {
+	return strings.IndexFunc(fl.Field().String(), func(r rune) bool {
+		return !unicode.IsPrint(r)
+	}) == -1
+}
+
+func multiLine(fl validator.FieldLevel) bool {
+	return strings.IndexFunc(fl.Field().String(), func(r rune) bool {
+		return !unicode.IsPrint(r) && r != '\n' && r != '\r' && r != '\t'
+	}) == -1
+}
+
+func httpURL(fl validator.FieldLevel) bool {
+	u, err := url.Parse(fl.Field().String())
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type request struct {
+	Name        string `json:"name" binding:"required,notblank,max=10,singleline"`
+	Description string `json:"description" binding:"max=10,multiline"`
+	Limit       int    `form:"limit" binding:"min=1,max=100"`
+	Id          int    `uri:"id" binding:"required,min=1"`
+}
+
+func TestError(t *testing.T) {
+	require.NoError(t, Register())
+
+	tests := []struct {
+		name    string
+		request request
+		fields  []domain.FieldError
+	}{
+		{
+			name:    "valid",
+			request: request{Name: "name", Description: "a\nb", Limit: 1, Id: 1},
+		},
+		{
+			name:    "too long and out of range",
+			request: request{Name: strings.Repeat("a", 11), Limit: 101, Id: 1},
+			fields: []domain.FieldError{
+				{Field: "name", Code: domain.FieldTooLong, Message: "name must be at most 10 characters"},
+				{Field: "limit", Code: domain.FieldOutOfRange, Message: "limit must be at most 100"},
+			},
+		},
+		{
+			name:    "blank and control characters",
+			request: request{Name: "  ", Description: "a\x00", Limit: 1, Id: 1},
+			fields: []domain.FieldError{
+				{Field: "name", Code: domain.FieldRequired, Message: "name must not be blank"},
+				{Field: "description", Code: domain.FieldInvalidChars, Message: "description must contain printable characters and line breaks only"},
+			},
+		},
+		{
+			name:    "required",
+			request: request{Name: "a\tb", Limit: 1},
+			fields: []domain.FieldError{
+				{Field: "name", Code: domain.FieldInvalidChars, Message: "name must contain printable characters only"},
+				{Field: "id", Code: domain.FieldRequired, Message: "id is required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := binding.Validator.ValidateStruct(&tt.request)
+			if tt.fields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var domainErr *domain.Error
+			require.True(t, errors.As(Error(err), &domainErr))
+			assert.Equal(t, domain.KindValidation, domainErr.Kind)
+			assert.Equal(t, tt.fields, domainErr.Fields)
+		})
+	}
+}
+
+func TestBind(t *testing.T) {
+	require.NoError(t, Register())
+	gin.SetMode(gin.TestMode)
+
+	type query struct {
+		Limit int `form:"limit" binding:"min=1,max=100"`
+	}
+
+	tests := []struct {
+		query   string
+		ok      bool
+		invalid bool
+	}{
+		{query: "limit=10", ok: true},
+		{query: "limit=0", invalid: true},
+		{query: "limit=many", invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+
+			var q query
+			assert.Equal(t, tt.ok, Bind(c, &q, c.ShouldBindQuery))
+			assert.Equal(t, tt.invalid, c.IsAborted())
+			if tt.invalid {
+				var domainErr *domain.Error
+				require.True(t, errors.As(c.Errors.Last(), &domainErr))
+				assert.Equal(t, domain.KindValidation, domainErr.Kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package controller
+
+import "goods-manager/internal/domain/entity"
+
+// WebhookRequest is subscription of URL to events of the project
+type WebhookRequest struct {
+	URL string `json:"url" binding:"required,max=2048,httpurl" maxLength:"2048" example:"https://example.com/hooks/goods"`
+
+	// EventTypes filters delivered events, all events are delivered if it is empty
+	EventTypes []entity.EventType `json:"event_types" binding:"max=4,dive,oneof=good.created good.updated good.deleted good.reprioritized" enums:"good.created,good.updated,good.deleted,good.reprioritized"`
+}
+
+// PatchWebhookRequest changes passed fields of webhook, enabling of webhook resets its failures
+type PatchWebhookRequest struct {
+	URL        *string            `json:"url" binding:"omitempty,max=2048,httpurl" maxLength:"2048"`
+	EventTypes []entity.EventType `json:"event_types" binding:"omitempty,max=4,dive,oneof=good.created good.updated good.deleted good.reprioritized" enums:"good.created,good.updated,good.deleted,good.reprioritized"`
+	Enabled    *bool              `json:"enabled"`
+}
+
+type ProjectPath struct {
+	ProjectId int `uri:"projectId" binding:"required,min=1"`
+}
+
+type WebhookPath struct {
+	ProjectId int `uri:"projectId" binding:"required,min=1"`
+	Id        int `uri:"id" binding:"required,min=1"`
+}
+
+type PageQuery struct {
+	Limit  int `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset int `form:"offset,default=0" binding:"min=0"`
+}
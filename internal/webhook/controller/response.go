@@ -0,0 +1,20 @@
+package controller
+
+import "goods-manager/internal/domain/entity"
+
+type CreateWebhookResponse struct {
+	*entity.Webhook
+
+	// Secret signs webhook requests, it is returned only once
+	Secret string `json:"secret"`
+}
+
+type ListWebhooksResponse struct {
+	Webhooks []*entity.Webhook `json:"webhooks"`
+}
+
+type ListDeliveriesResponse struct {
+	Deliveries []*entity.WebhookDelivery `json:"deliveries"`
+	Limit      int                       `json:"limit"`
+	Offset     int                       `json:"offset"`
+}
@@ -0,0 +1,268 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/httperror"
+	"goods-manager/internal/validation"
+	"net/http"
+)
+
+// WebhookController serves webhooks of a project by routes `/v2/projects/:projectId/webhooks[/:id]`
+type WebhookController struct {
+	webhookUsecase domain.WebhookUsecase
+}
+
+// getWebhookFromPath retrieves a Webhook by `projectId` and `id` path parameters, webhook of another project is not found.
+// If the Webhook can't be retrieved, the request is aborted with the error and nil is returned.
+func (w *WebhookController) getWebhookFromPath(c *gin.Context) *entity.Webhook {
+	var path WebhookPath
+	if !validation.Bind(c, &path, c.ShouldBindUri) {
+		return nil
+	}
+
+	webhook, err := w.webhookUsecase.Get(c, path.Id)
+	if err != nil {
+		httperror.Abort(c, err)
+		return nil
+	}
+
+	if webhook.ProjectId != path.ProjectId {
+		httperror.Abort(c, domain.ErrorWebhookNotFound)
+		return nil
+	}
+
+	return webhook
+}
+
+// Create this function is used to subscribe URL to events of the project.
+//
+// @Summary		Create webhook
+// @Description	Requests are signed by returned secret: `X-Webhook-Signature` is `sha256=` and hex of HMAC-SHA256 of `<X-Webhook-Timestamp>.<body>`.
+// @Tags		webhooks
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Accept		json
+// @Produce		json
+//
+// @Param		projectId	path		int				true	"Project ID"	minimum(1)
+// @Param		webhook		body		WebhookRequest	true	"URL and event types"
+//
+// @Success		201		{object}	CreateWebhookResponse	"Webhook with secret"
+// @Header		201		{string}	Location				"URL of the webhook"
+// @Failure		400		{object}	httperror.Response		"Invalid input"
+// @Failure		401		{object}	httperror.Response		"Unauthorized"
+// @Failure		403		{object}	httperror.Response		"Project is out of scope or role is not enough"
+// @Failure		429		{object}	httperror.Response		"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response		"Server error"
+// @Router		/v2/projects/{projectId}/webhooks		[post]
+func (w *WebhookController) Create(c *gin.Context) {
+	var path ProjectPath
+	if !validation.Bind(c, &path, c.ShouldBindUri) {
+		return
+	}
+
+	var request WebhookRequest
+	if !validation.Bind(c, &request, c.ShouldBindJSON) {
+		return
+	}
+
+	webhook := entity.Webhook{ProjectId: path.ProjectId, URL: request.URL, EventTypes: request.EventTypes}
+	if err := w.webhookUsecase.Create(c, &webhook); err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/v2/projects/%d/webhooks/%d", webhook.ProjectId, webhook.Id))
+	c.JSON(http.StatusCreated, CreateWebhookResponse{Webhook: &webhook, Secret: webhook.Secret})
+}
+
+// List this function is used for get webhooks of the project.
+//
+// @Summary		Get webhooks of the project
+// @Tags		webhooks
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		projectId	path		int			true	"Project ID"	minimum(1)
+//
+// @Success		200		{object}	ListWebhooksResponse	"Webhooks without secrets"
+// @Failure		400		{object}	httperror.Response		"Invalid input"
+// @Failure		401		{object}	httperror.Response		"Unauthorized"
+// @Failure		403		{object}	httperror.Response		"Project is out of scope or role is not enough"
+// @Failure		429		{object}	httperror.Response		"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response		"Server error"
+// @Router		/v2/projects/{projectId}/webhooks		[get]
+func (w *WebhookController) List(c *gin.Context) {
+	var path ProjectPath
+	if !validation.Bind(c, &path, c.ShouldBindUri) {
+		return
+	}
+
+	webhooks, err := w.webhookUsecase.List(c, path.ProjectId)
+	if err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListWebhooksResponse{Webhooks: webhooks})
+}
+
+// Get this function is used for get webhook.
+//
+// @Summary		Get webhook
+// @Tags		webhooks
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		projectId	path		int			true	"Project ID"	minimum(1)
+// @Param		id			path		int			true	"ID of webhook"	minimum(1)
+//
+// @Success		200		{object}	entity.Webhook		"Webhook without secret"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response	"Webhook not found"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/webhooks/{id}		[get]
+func (w *WebhookController) Get(c *gin.Context) {
+	webhook := w.getWebhookFromPath(c)
+	if webhook == nil {
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// Patch this function updates passed fields of the webhook.
+//
+// @Summary		Update webhook partially
+// @Description	Disabled webhook is enabled by `{"enabled": true}`, its failures are reset.
+// @Tags		webhooks
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Accept		json
+// @Produce		json
+//
+// @Param		projectId	path		int					true	"Project ID"	minimum(1)
+// @Param		id			path		int					true	"ID of webhook"	minimum(1)
+// @Param		webhook		body		PatchWebhookRequest	true	"Changed fields of the webhook"
+//
+// @Success		200		{object}	entity.Webhook		"Webhook that was updated"
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response	"Webhook not found"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/webhooks/{id}		[patch]
+func (w *WebhookController) Patch(c *gin.Context) {
+	var request PatchWebhookRequest
+	if !validation.Bind(c, &request, c.ShouldBindJSON) {
+		return
+	}
+
+	webhook := w.getWebhookFromPath(c)
+	if webhook == nil {
+		return
+	}
+
+	if request.URL != nil {
+		webhook.URL = *request.URL
+	}
+	if request.EventTypes != nil {
+		webhook.EventTypes = request.EventTypes
+	}
+	if request.Enabled != nil {
+		webhook.Enabled = *request.Enabled
+	}
+
+	if err := w.webhookUsecase.Update(c, webhook); err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// Delete this function deletes webhook with its deliveries.
+//
+// @Summary		Delete webhook
+// @Tags		webhooks
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+//
+// @Param		projectId	path		int			true	"Project ID"	minimum(1)
+// @Param		id			path		int			true	"ID of webhook"	minimum(1)
+//
+// @Success		204
+// @Failure		400		{object}	httperror.Response	"Invalid input"
+// @Failure		401		{object}	httperror.Response	"Unauthorized"
+// @Failure		403		{object}	httperror.Response	"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response	"Webhook not found"
+// @Failure		429		{object}	httperror.Response	"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response	"Server error"
+// @Router		/v2/projects/{projectId}/webhooks/{id}		[delete]
+func (w *WebhookController) Delete(c *gin.Context) {
+	webhook := w.getWebhookFromPath(c)
+	if webhook == nil {
+		return
+	}
+
+	if err := w.webhookUsecase.Delete(c, webhook.Id); err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Deliveries this function is used for get delivery log of the webhook.
+//
+// @Summary		Get deliveries of webhook
+// @Tags		webhooks
+// @Security	ApiKeyAuth
+// @Security	BearerAuth
+// @Produce		json
+//
+// @Param		projectId	path		int			true	"Project ID"	minimum(1)
+// @Param		id			path		int			true	"ID of webhook"	minimum(1)
+// @Param		offset		query		int			false	"Offset of select"	minimum(0)	default(0)
+// @Param		limit		query		int			false	"Limit of rows"		minimum(1)	maximum(100)	default(20)
+//
+// @Success		200		{object}	ListDeliveriesResponse	"Deliveries, the latest first"
+// @Failure		400		{object}	httperror.Response		"Invalid input"
+// @Failure		401		{object}	httperror.Response		"Unauthorized"
+// @Failure		403		{object}	httperror.Response		"Project is out of scope or role is not enough"
+// @Failure		404		{object}	httperror.Response		"Webhook not found"
+// @Failure		429		{object}	httperror.Response		"Rate limit exceeded"
+// @Failure		500		{object}	httperror.Response		"Server error"
+// @Router		/v2/projects/{projectId}/webhooks/{id}/deliveries		[get]
+func (w *WebhookController) Deliveries(c *gin.Context) {
+	var page PageQuery
+	if !validation.Bind(c, &page, c.ShouldBindQuery) {
+		return
+	}
+
+	webhook := w.getWebhookFromPath(c)
+	if webhook == nil {
+		return
+	}
+
+	deliveries, err := w.webhookUsecase.ListDeliveries(c, webhook.Id, page.Limit, page.Offset)
+	if err != nil {
+		httperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListDeliveriesResponse{Deliveries: deliveries, Limit: page.Limit, Offset: page.Offset})
+}
+
+func NewWebhookController(webhookUsecase domain.WebhookUsecase) *WebhookController {
+	return &WebhookController{webhookUsecase: webhookUsecase}
+}
@@ -0,0 +1,383 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
+	"goods-manager/internal/transactor"
+	"time"
+)
+
+type webhookRepository struct {
+	transactor *transactor.Transactor
+}
+
+// Create inserts webhook and sets its ID, state and creation timestamp
+func (w *webhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (err error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.Create")
+	defer tracing.End(span, &err)
+
+	query := `
+		INSERT INTO webhooks (project_id, url, secret, event_types)
+			VALUES ($1, $2, $3, $4)
+		RETURNING id, enabled, failures, created_at
+	`
+
+	tx, db := w.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, webhook.ProjectId, webhook.URL, webhook.Secret, eventTypesArray(webhook.EventTypes))
+	} else {
+		row = db.QueryRowContext(ctx, query, webhook.ProjectId, webhook.URL, webhook.Secret, eventTypesArray(webhook.EventTypes))
+	}
+
+	return row.Scan(&webhook.Id, &webhook.Enabled, &webhook.Failures, &webhook.CreatedAt)
+}
+
+// Get gets webhook by ID
+func (w *webhookRepository) Get(ctx context.Context, id int) (_ *entity.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.Get")
+	defer tracing.End(span, &err)
+
+	query := `
+		SELECT id, project_id, url, secret, event_types, enabled, failures, created_at FROM webhooks
+			WHERE id = $1
+	`
+
+	tx, db := w.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, id)
+	} else {
+		row = db.QueryRowContext(ctx, query, id)
+	}
+
+	webhook, err := scanWebhook(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrorWebhookNotFound
+		}
+
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// List gets webhooks of the project
+func (w *webhookRepository) List(ctx context.Context, projectId int) (_ []*entity.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.List")
+	defer tracing.End(span, &err)
+
+	query := `
+		SELECT id, project_id, url, secret, event_types, enabled, failures, created_at FROM webhooks
+			WHERE project_id = $1
+			ORDER BY id
+	`
+
+	tx, db := w.transactor.Connection(ctx)
+	var rows *sql.Rows
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, projectId)
+	} else {
+		rows, err = db.QueryContext(ctx, query, projectId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeRows(rows, &err)
+
+	webhooks := make([]*entity.Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// Update updates URL, event types and state of the webhook, enabled webhook gets zero failures
+func (w *webhookRepository) Update(ctx context.Context, webhook *entity.Webhook) (err error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.Update")
+	defer tracing.End(span, &err)
+
+	query := `
+		UPDATE webhooks SET url = $1, event_types = $2, enabled = $3,
+		                    failures = CASE WHEN $3 AND NOT enabled THEN 0 ELSE failures END
+			WHERE id = $4
+		RETURNING failures
+	`
+
+	tx, db := w.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, webhook.URL, eventTypesArray(webhook.EventTypes), webhook.Enabled, webhook.Id)
+	} else {
+		row = db.QueryRowContext(ctx, query, webhook.URL, eventTypesArray(webhook.EventTypes), webhook.Enabled, webhook.Id)
+	}
+
+	if err := row.Scan(&webhook.Failures); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrorWebhookNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Delete deletes the webhook, its deliveries are deleted by cascade
+func (w *webhookRepository) Delete(ctx context.Context, id int) (err error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.Delete")
+	defer tracing.End(span, &err)
+
+	query := `
+		DELETE FROM webhooks WHERE id = $1
+	`
+
+	tx, db := w.transactor.Connection(ctx)
+	var result sql.Result
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, query, id)
+	} else {
+		result, err = db.ExecContext(ctx, query, id)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrorWebhookNotFound
+	}
+
+	return nil
+}
+
+// ListDeliveries gets deliveries of the webhook, the latest first
+func (w *webhookRepository) ListDeliveries(ctx context.Context, webhookId, limit, offset int) (_ []*entity.WebhookDelivery, err error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.ListDeliveries")
+	defer tracing.End(span, &err)
+
+	query := `
+		SELECT id, webhook_id, event_id, event_type, payload, status, attempts,
+		       COALESCE(response_status, 0), COALESCE(error, ''), next_attempt_at, created_at
+		FROM webhook_deliveries
+			WHERE webhook_id = $1
+			ORDER BY id DESC
+			LIMIT $2 OFFSET $3
+	`
+
+	tx, db := w.transactor.Connection(ctx)
+	var rows *sql.Rows
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, webhookId, limit, offset)
+	} else {
+		rows, err = db.QueryContext(ctx, query, webhookId, limit, offset)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeRows(rows, &err)
+
+	deliveries := make([]*entity.WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+		err := rows.Scan(&delivery.Id, &delivery.WebhookId, &delivery.EventId, &delivery.EventType, &delivery.Payload, &delivery.Status,
+			&delivery.Attempts, &delivery.ResponseStatus, &delivery.Error, &delivery.NextAttemptAt, &delivery.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// CreateDeliveries creates pending deliveries of the event for enabled webhooks of the project which accept its type
+func (w *webhookRepository) CreateDeliveries(ctx context.Context, projectId int, eventId string, eventType entity.EventType, payload []byte) (err error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.CreateDeliveries")
+	defer tracing.End(span, &err)
+
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+		SELECT id, $2, $3, $4 FROM webhooks
+			WHERE project_id = $1 AND enabled = true
+				AND (cardinality(event_types) = 0 OR $3 = ANY (event_types))
+	`
+
+	tx, db := w.transactor.Connection(ctx)
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, query, projectId, eventId, eventType, payload)
+	} else {
+		_, err = db.ExecContext(ctx, query, projectId, eventId, eventType, payload)
+	}
+
+	return err
+}
+
+// ClaimDeliveries postpones due pending deliveries for `lease` and returns them with their webhooks.
+//
+// Locked rows are skipped, so instances don't claim the same deliveries.
+func (w *webhookRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) (_ []*entity.WebhookDelivery, err error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.ClaimDeliveries")
+	defer tracing.End(span, &err)
+
+	query := `
+		UPDATE webhook_deliveries d SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM webhooks w
+			WHERE d.webhook_id = w.id AND d.id IN (
+				SELECT id FROM webhook_deliveries
+					WHERE status = 'pending' AND next_attempt_at <= NOW()
+					ORDER BY next_attempt_at, id
+					LIMIT $1
+					FOR UPDATE SKIP LOCKED
+			)
+		RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at, d.created_at,
+		          w.project_id, w.url, w.secret, w.enabled, w.failures
+	`
+
+	tx, db := w.transactor.Connection(ctx)
+	var rows *sql.Rows
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, limit, lease.Seconds())
+	} else {
+		rows, err = db.QueryContext(ctx, query, limit, lease.Seconds())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeRows(rows, &err)
+
+	deliveries := make([]*entity.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery := entity.WebhookDelivery{Webhook: &entity.Webhook{}}
+		err := rows.Scan(&delivery.Id, &delivery.WebhookId, &delivery.EventId, &delivery.EventType, &delivery.Payload, &delivery.Status,
+			&delivery.Attempts, &delivery.NextAttemptAt, &delivery.CreatedAt,
+			&delivery.Webhook.ProjectId, &delivery.Webhook.URL, &delivery.Webhook.Secret, &delivery.Webhook.Enabled, &delivery.Webhook.Failures)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.Webhook.Id = delivery.WebhookId
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// SaveAttempt saves result of the delivery and failures of its webhook by one statement.
+// Failures of disabled webhook aren't changed, so it isn't disabled again.
+func (w *webhookRepository) SaveAttempt(ctx context.Context, delivery *entity.WebhookDelivery, retryIn time.Duration, disableAfter int) (_ bool, err error) {
+	ctx, span := tracing.Start(ctx, "webhookRepository.SaveAttempt")
+	defer tracing.End(span, &err)
+
+	query := `
+		WITH delivery AS (
+			UPDATE webhook_deliveries SET status = $2, attempts = $3, response_status = NULLIF($4, 0), error = NULLIF($5, ''),
+			                              next_attempt_at = NOW() + make_interval(secs => $6), updated_at = NOW()
+				WHERE id = $1
+			RETURNING webhook_id
+		)
+		UPDATE webhooks w SET failures = CASE WHEN $2 = 'delivered' THEN 0 ELSE w.failures + 1 END,
+		                      enabled = CASE WHEN $2 = 'delivered' THEN w.enabled ELSE w.enabled AND w.failures + 1 < $7 END
+			FROM delivery
+			WHERE w.id = delivery.webhook_id AND w.enabled
+		RETURNING w.enabled
+	`
+
+	args := []any{delivery.Id, delivery.Status, delivery.Attempts, delivery.ResponseStatus, delivery.Error, retryIn.Seconds(), disableAfter}
+	tx, db := w.transactor.Connection(ctx)
+	var row *sql.Row
+	if tx != nil {
+		row = tx.QueryRowContext(ctx, query, args...)
+	} else {
+		row = db.QueryRowContext(ctx, query, args...)
+	}
+
+	var enabled bool
+	if err := row.Scan(&enabled); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// webhook is deleted or disabled while delivery is in progress
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return delivery.Webhook != nil && delivery.Webhook.Enabled && !enabled, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWebhook(row scanner) (*entity.Webhook, error) {
+	var webhook entity.Webhook
+	var eventTypes []string
+	err := row.Scan(&webhook.Id, &webhook.ProjectId, &webhook.URL, &webhook.Secret, pq.Array(&eventTypes),
+		&webhook.Enabled, &webhook.Failures, &webhook.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.EventTypes = make([]entity.EventType, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		webhook.EventTypes = append(webhook.EventTypes, entity.EventType(eventType))
+	}
+
+	return &webhook, nil
+}
+
+func eventTypesArray(eventTypes []entity.EventType) any {
+	values := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		values = append(values, string(eventType))
+	}
+
+	return pq.Array(values)
+}
+
+func closeRows(rows *sql.Rows, err *error) {
+	if closeErr := rows.Close(); closeErr != nil {
+		*err = errors.Join(*err, fmt.Errorf("failed close rows: %w", closeErr))
+	}
+}
+
+func NewWebhookRepository(transactor *transactor.Transactor) domain.WebhookRepository {
+	return &webhookRepository{transactor: transactor}
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/transactor"
+	"testing"
+	"time"
+)
+
+func Test_webhookRepository_SaveAttempt(t *testing.T) {
+	tests := []struct {
+		name string
+		// rows are enabled flags returned by update of webhook, no rows if it is disabled or deleted
+		rows         []bool
+		wantDisabled bool
+	}{
+		{name: "webhook stays enabled", rows: []bool{true}},
+		{name: "webhook is disabled by the failure", rows: []bool{false}, wantDisabled: true},
+		{name: "webhook is already disabled", rows: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			rows := sqlmock.NewRows([]string{"enabled"})
+			for _, enabled := range tt.rows {
+				rows.AddRow(enabled)
+			}
+
+			delivery := &entity.WebhookDelivery{Id: 1, WebhookId: 2, Status: entity.DeliveryFailed, Attempts: 3, Error: "timeout",
+				Webhook: &entity.Webhook{Id: 2, Enabled: true, Failures: 4}}
+			mock.ExpectQuery(`UPDATE webhooks w SET .+ WHERE w.id = delivery.webhook_id AND w.enabled`).
+				WithArgs(delivery.Id, delivery.Status, delivery.Attempts, delivery.ResponseStatus, delivery.Error, float64(60), 5).
+				WillReturnRows(rows)
+
+			repo := NewWebhookRepository(transactor.NewTransactor(db))
+			disabled, err := repo.SaveAttempt(context.Background(), delivery, time.Minute, 5)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDisabled, disabled)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// errForbiddenAddress is returned by dial of address which isn't public
+var errForbiddenAddress = errors.New("address is not public")
+
+// newClient returns client of webhook requests. Redirects aren't followed, so response with 3xx status is failed attempt.
+// Connections to loopback, private, link-local and unspecified addresses are refused unless `allowPrivate`,
+// so webhooks can't reach internal services.
+func newClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivate {
+		// proxy would connect to the checked address instead of the client
+		transport.Proxy = nil
+		transport.DialContext = publicDialContext(dialer)
+	}
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// publicDialContext resolves host of address and refuses to dial if any of its IPs isn't public.
+// Checked IP is dialed, so host can't be resolved to another IP between check and dial.
+func publicDialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+
+		ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		if len(ips) == 0 {
+			return nil, fmt.Errorf("host %s has no addresses", host)
+		}
+
+		for _, ip := range ips {
+			if !isPublic(ip.IP) {
+				return nil, fmt.Errorf("failed dial %s: %w: %s", host, errForbiddenAddress, ip.IP)
+			}
+		}
+
+		return dialer.DialContext(ctx, network, net.JoinHostPort(ips[0].IP.String(), port))
+	}
+}
+
+// isPublic is false for loopback, private, link-local, multicast and unspecified IPs
+func isPublic(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
@@ -0,0 +1,348 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"goods-manager/internal/domain"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/internal/tracing"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Headers of webhook requests
+const (
+	EventIdHeader   = "X-Webhook-Id"
+	EventTypeHeader = "X-Webhook-Event"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+const (
+	// secretPrefix marks webhook secrets
+	secretPrefix = "whsec_"
+
+	// maxErrorLength is max length of stored error of attempt
+	maxErrorLength = 1024
+)
+
+// Config is configuration of webhook deliveries
+type Config struct {
+	// MaxAttempts is count of attempts after which delivery is failed
+	MaxAttempts int
+
+	// BackoffBase is delay after the first failed attempt, it doubles after every next one
+	BackoffBase time.Duration
+
+	// BackoffMax is max delay between attempts
+	BackoffMax time.Duration
+
+	// DisableAfter is count of consecutive failed attempts after which webhook is disabled
+	DisableAfter int
+
+	// Timeout of webhook request
+	Timeout time.Duration
+
+	// AllowPrivateNetworks allows webhooks to loopback, private and link-local addresses, e.g. for local development
+	AllowPrivateNetworks bool
+}
+
+// DefaultConfig is used for zero values of `Config`
+var DefaultConfig = Config{
+	MaxAttempts:  8,
+	BackoffBase:  10 * time.Second,
+	BackoffMax:   1 * time.Hour,
+	DisableAfter: 20,
+	Timeout:      10 * time.Second,
+}
+
+// webhookUsecase implementation `domain.WebhookUsecase`.
+type webhookUsecase struct {
+	webhookRepo domain.WebhookRepository
+	client      *http.Client
+	config      Config
+	logger      *slog.Logger
+}
+
+// Create webhook with generated secret
+func (w *webhookUsecase) Create(ctx context.Context, webhook *entity.Webhook) (err error) {
+	ctx, span := tracing.Start(ctx, "webhookUsecase.Create")
+	defer tracing.End(span, &err)
+
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return fmt.Errorf("failed generate secret: %w", err)
+	}
+	webhook.Secret = secretPrefix + hex.EncodeToString(secret)
+
+	if err := w.webhookRepo.Create(ctx, webhook); err != nil {
+		return err
+	}
+
+	w.logger.InfoContext(ctx, "webhook created", slog.Int("id", webhook.Id), slog.Int("project_id", webhook.ProjectId))
+	return nil
+}
+
+func (w *webhookUsecase) Get(ctx context.Context, id int) (_ *entity.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "webhookUsecase.Get")
+	defer tracing.End(span, &err)
+
+	return w.webhookRepo.Get(ctx, id)
+}
+
+func (w *webhookUsecase) List(ctx context.Context, projectId int) (_ []*entity.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "webhookUsecase.List")
+	defer tracing.End(span, &err)
+
+	return w.webhookRepo.List(ctx, projectId)
+}
+
+// Update URL, event types and state of webhook
+func (w *webhookUsecase) Update(ctx context.Context, webhook *entity.Webhook) (err error) {
+	ctx, span := tracing.Start(ctx, "webhookUsecase.Update")
+	defer tracing.End(span, &err)
+
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+
+	if err := w.webhookRepo.Update(ctx, webhook); err != nil {
+		return err
+	}
+
+	w.logger.InfoContext(ctx, "webhook updated", slog.Int("id", webhook.Id), slog.Bool("enabled", webhook.Enabled))
+	return nil
+}
+
+func (w *webhookUsecase) Delete(ctx context.Context, id int) (err error) {
+	ctx, span := tracing.Start(ctx, "webhookUsecase.Delete")
+	defer tracing.End(span, &err)
+
+	if err := w.webhookRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	w.logger.InfoContext(ctx, "webhook deleted", slog.Int("id", id))
+	return nil
+}
+
+func (w *webhookUsecase) ListDeliveries(ctx context.Context, webhookId, limit, offset int) (_ []*entity.WebhookDelivery, err error) {
+	ctx, span := tracing.Start(ctx, "webhookUsecase.ListDeliveries")
+	defer tracing.End(span, &err)
+
+	return w.webhookRepo.ListDeliveries(ctx, webhookId, limit, offset)
+}
+
+// Publish stores deliveries of the event, they are sent by `Dispatch` after commit of transaction
+func (w *webhookUsecase) Publish(ctx context.Context, projectId int, eventType entity.EventType, data any) (err error) {
+	ctx, span := tracing.Start(ctx, "webhookUsecase.Publish", trace.WithAttributes(attribute.String("event.type", string(eventType))))
+	defer tracing.End(span, &err)
+
+	event := entity.WebhookEvent{
+		Id:         uuid.NewString(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		ProjectId:  projectId,
+		Data:       data,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return w.webhookRepo.CreateDeliveries(ctx, projectId, event.Id, eventType, payload)
+}
+
+// Dispatch claims due deliveries and sends them concurrently
+func (w *webhookUsecase) Dispatch(ctx context.Context, limit int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "webhookUsecase.Dispatch")
+	defer tracing.End(span, &err)
+
+	// claimed deliveries are retried after lease if instance stops during attempt
+	deliveries, err := w.webhookRepo.ClaimDeliveries(ctx, limit, 2*w.config.Timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery *entity.WebhookDelivery) {
+			defer wg.Done()
+			w.attempt(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// attempt sends the delivery and saves result of the attempt
+func (w *webhookUsecase) attempt(ctx context.Context, delivery *entity.WebhookDelivery) {
+	ctx, span := tracing.Start(ctx, "webhookUsecase.attempt", trace.WithAttributes(
+		attribute.Int64("webhook.delivery.id", delivery.Id),
+		attribute.Int("webhook.id", delivery.WebhookId),
+	))
+	var err error
+	defer tracing.End(span, &err)
+
+	logger := w.logger.With(slog.Int64("delivery_id", delivery.Id), slog.Int("webhook_id", delivery.WebhookId))
+
+	var retryIn time.Duration
+	if !delivery.Webhook.Enabled {
+		delivery.Status, delivery.Error = entity.DeliveryFailed, "webhook is disabled"
+	} else {
+		delivery.Attempts++
+		delivery.ResponseStatus, err = w.send(ctx, delivery)
+
+		switch {
+		case err == nil:
+			delivery.Status, delivery.Error = entity.DeliveryDelivered, ""
+		case delivery.Attempts >= w.config.MaxAttempts:
+			delivery.Status, delivery.Error = entity.DeliveryFailed, truncate(err.Error())
+		default:
+			delivery.Status, delivery.Error = entity.DeliveryPending, truncate(err.Error())
+			retryIn = w.backoff(delivery.Attempts)
+		}
+	}
+
+	disabled, saveErr := w.webhookRepo.SaveAttempt(ctx, delivery, retryIn, w.config.DisableAfter)
+	if saveErr != nil {
+		logger.ErrorContext(ctx, "failed save webhook delivery attempt", slog.Any("error", saveErr))
+	}
+
+	if err != nil {
+		logger.WarnContext(ctx, "webhook delivery attempt failed", slog.Int("attempts", delivery.Attempts),
+			slog.String("status", string(delivery.Status)), slog.Duration("retry_in", retryIn), slog.Any("error", err))
+	}
+	if disabled {
+		logger.WarnContext(ctx, "webhook disabled after failed deliveries", slog.Int("failures", w.config.DisableAfter))
+	}
+}
+
+// send posts signed payload to URL of webhook and returns status of response.
+// Response without 2xx status is an error.
+func (w *webhookUsecase) send(ctx context.Context, delivery *entity.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "goods-manager-webhooks")
+	req.Header.Set(EventIdHeader, delivery.EventId)
+	req.Header.Set(EventTypeHeader, string(delivery.EventType))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(delivery.Webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// connection is reused only if body is read
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff returns delay after `attempts` failed attempts
+func (w *webhookUsecase) backoff(attempts int) time.Duration {
+	delay := w.config.BackoffBase
+	for i := 1; i < attempts && delay < w.config.BackoffMax; i++ {
+		delay *= 2
+	}
+
+	return min(delay, w.config.BackoffMax)
+}
+
+// Sign returns value of `SignatureHeader`: HMAC-SHA256 of `<timestamp>.<payload>` by the secret
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// validateWebhook checks fields of the webhook
+func validateWebhook(webhook *entity.Webhook) error {
+	var fields []domain.FieldError
+	if webhook.ProjectId < 1 {
+		fields = append(fields, domain.FieldError{Field: "projectId", Code: domain.FieldInvalid, Message: "projectId must be greater than 0"})
+	}
+	if webhook.URL == "" {
+		fields = append(fields, domain.FieldError{Field: "url", Code: domain.FieldRequired, Message: "url is required"})
+	}
+	for _, eventType := range webhook.EventTypes {
+		if !eventType.Valid() {
+			fields = append(fields, domain.FieldError{Field: "event_types", Code: domain.FieldInvalid, Message: "event type " + string(eventType) + " is unknown"})
+		}
+	}
+
+	if len(fields) > 0 {
+		return domain.NewValidationError("invalid webhook", fields...)
+	}
+
+	return nil
+}
+
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength]
+	}
+
+	return s
+}
+
+func NewWebhookUsecase(webhookRepo domain.WebhookRepository, config Config, logger *slog.Logger) domain.WebhookUsecase {
+	if config.MaxAttempts == 0 {
+		config.MaxAttempts = DefaultConfig.MaxAttempts
+	}
+	if config.BackoffBase == 0 {
+		config.BackoffBase = DefaultConfig.BackoffBase
+	}
+	if config.BackoffMax == 0 {
+		config.BackoffMax = DefaultConfig.BackoffMax
+	}
+	if config.DisableAfter == 0 {
+		config.DisableAfter = DefaultConfig.DisableAfter
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultConfig.Timeout
+	}
+
+	return &webhookUsecase{
+		webhookRepo: webhookRepo,
+		client:      newClient(config.AllowPrivateNetworks),
+		config:      config,
+		logger:      logger,
+	}
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"goods-manager/internal/domain/entity"
+	"goods-manager/mocks"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestUsecase(t *testing.T) (*webhookUsecase, *mocks.WebhookRepository) {
+	repo := mocks.NewWebhookRepository(t)
+	usecase := NewWebhookUsecase(repo, Config{MaxAttempts: 3, BackoffBase: time.Second, BackoffMax: 3 * time.Second, AllowPrivateNetworks: true}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	return usecase.(*webhookUsecase), repo
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163", Sign("secret", "1700000000", []byte("{}")))
+	assert.NotEqual(t, Sign("secret", "1", []byte("{}")), Sign("other", "1", []byte("{}")))
+	assert.NotEqual(t, Sign("secret", "1", []byte("{}")), Sign("secret", "2", []byte("{}")))
+}
+
+func Test_webhookUsecase_backoff(t *testing.T) {
+	usecase, _ := newTestUsecase(t)
+
+	assert.Equal(t, time.Second, usecase.backoff(1))
+	assert.Equal(t, 2*time.Second, usecase.backoff(2))
+	assert.Equal(t, 3*time.Second, usecase.backoff(3))
+	assert.Equal(t, 3*time.Second, usecase.backoff(30))
+}
+
+func Test_webhookUsecase_Publish(t *testing.T) {
+	usecase, repo := newTestUsecase(t)
+	good := &entity.Good{Id: 5, ProjectId: 2, Name: "name"}
+
+	repo.On("CreateDeliveries", mock.Anything, 2, mock.Anything, entity.EventGoodCreated, mock.Anything).
+		Run(func(args mock.Arguments) {
+			var event map[string]any
+			require.NoError(t, json.Unmarshal(args.Get(4).([]byte), &event))
+			assert.Equal(t, args.Get(2), event["id"])
+			assert.Equal(t, "good.created", event["type"])
+			assert.Equal(t, "name", event["data"].(map[string]any)["name"])
+		}).
+		Return(nil)
+
+	assert.NoError(t, usecase.Publish(context.Background(), 2, entity.EventGoodCreated, good))
+}
+
+func Test_webhookUsecase_Dispatch(t *testing.T) {
+	statuses := map[string]int{"/ok": http.StatusOK, "/fail": http.StatusInternalServerError}
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path == "/ok" {
+			signature = Sign("secret", r.Header.Get(TimestampHeader), body)
+			assert.Equal(t, signature, r.Header.Get(SignatureHeader))
+			assert.Equal(t, "good.created", r.Header.Get(EventTypeHeader))
+		}
+		w.WriteHeader(statuses[r.URL.Path])
+	}))
+	defer server.Close()
+
+	delivery := func(id int64, path string, attempts int, enabled bool) *entity.WebhookDelivery {
+		return &entity.WebhookDelivery{
+			Id: id, WebhookId: int(id), EventId: "event", EventType: entity.EventGoodCreated, Payload: []byte(`{}`), Attempts: attempts,
+			Webhook: &entity.Webhook{Id: int(id), URL: server.URL + path, Secret: "secret", Enabled: enabled},
+		}
+	}
+
+	usecase, repo := newTestUsecase(t)
+	repo.On("ClaimDeliveries", mock.Anything, 10, mock.Anything).Return([]*entity.WebhookDelivery{
+		delivery(1, "/ok", 0, true),
+		delivery(2, "/fail", 0, true),
+		delivery(3, "/fail", 2, true),
+		delivery(4, "/ok", 0, false),
+	}, nil)
+
+	type attempt struct {
+		delivery *entity.WebhookDelivery
+		retryIn  time.Duration
+	}
+	saved := make(chan attempt, 4)
+	repo.On("SaveAttempt", mock.Anything, mock.Anything, mock.Anything, 20).
+		Run(func(args mock.Arguments) {
+			saved <- attempt{delivery: args.Get(1).(*entity.WebhookDelivery), retryIn: args.Get(2).(time.Duration)}
+		}).
+		Return(false, nil)
+
+	count, err := usecase.Dispatch(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	close(saved)
+
+	results := make(map[int64]*entity.WebhookDelivery)
+	retries := make(map[int64]time.Duration)
+	for attempt := range saved {
+		results[attempt.delivery.Id] = attempt.delivery
+		retries[attempt.delivery.Id] = attempt.retryIn
+	}
+
+	assert.Equal(t, entity.DeliveryDelivered, results[1].Status)
+	assert.Equal(t, http.StatusOK, results[1].ResponseStatus)
+	assert.NotEmpty(t, signature)
+
+	assert.Equal(t, entity.DeliveryPending, results[2].Status)
+	assert.Equal(t, 1, results[2].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, results[2].ResponseStatus)
+	assert.Equal(t, time.Second, retries[2])
+
+	assert.Equal(t, entity.DeliveryFailed, results[3].Status)
+	assert.Equal(t, 3, results[3].Attempts)
+
+	assert.Equal(t, entity.DeliveryFailed, results[4].Status)
+	assert.Equal(t, 0, results[4].Attempts)
+}
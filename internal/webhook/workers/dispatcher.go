@@ -0,0 +1,41 @@
+package workers
+
+import (
+	"context"
+	"goods-manager/internal/domain"
+	"log/slog"
+	"time"
+)
+
+// DispatcherWorker delivers pending webhook deliveries
+type DispatcherWorker struct {
+	webhookUsecase domain.WebhookUsecase
+	interval       time.Duration
+	batchSize      int
+	logger         *slog.Logger
+}
+
+// Run dispatches batches of due deliveries until context is done.
+// The next batch is dispatched at once if batch is full, otherwise after `interval`.
+func (d *DispatcherWorker) Run(ctx context.Context) {
+	for {
+		count, err := d.webhookUsecase.Dispatch(ctx, d.batchSize)
+		if err != nil {
+			d.logger.ErrorContext(ctx, "failed dispatch webhook deliveries", slog.Any("error", err))
+		}
+
+		if err == nil && count == d.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(d.interval):
+		}
+	}
+}
+
+func NewDispatcherWorker(webhookUsecase domain.WebhookUsecase, interval time.Duration, batchSize int, logger *slog.Logger) *DispatcherWorker {
+	return &DispatcherWorker{webhookUsecase: webhookUsecase, interval: interval, batchSize: batchSize, logger: logger}
+}
@@ -1,12 +1,64 @@
 CREATE TABLE IF NOT EXISTS goods
 (
+    EventId    UUID,
+    EventType  LowCardinality(String),
+    EventTime  DateTime64(3, 'UTC') DEFAULT now64(3),
+    Actor      String,
     Id         INT,
     ProjectId INT,
     Name       String,
     Description Nullable(String),
     Priority   INT,
     Removed    BOOLEAN  DEFAULT false,
-    EventTime DateTime DEFAULT now()
+    -- state of good before the change as JSON, NULL for created good
+    Previous   Nullable(String)
 
-) ENGINE = MergeTree()
-      ORDER BY (Id, ProjectId, Name);
+) ENGINE = ReplacingMergeTree()
+      PARTITION BY toYYYYMM(EventTime)
+      -- event is redelivered with the same ID and time, so rows of the same event are merged to one
+      ORDER BY (ProjectId, Id, EventId);
+
+-- columns of event envelope for tables created by older versions, rows inserted before have default values
+ALTER TABLE goods
+    ADD COLUMN IF NOT EXISTS EventId   UUID FIRST,
+    ADD COLUMN IF NOT EXISTS EventType LowCardinality(String) AFTER EventId,
+    ADD COLUMN IF NOT EXISTS EventTime DateTime64(3, 'UTC') DEFAULT now64(3) AFTER EventType,
+    ADD COLUMN IF NOT EXISTS Actor     String AFTER EventTime,
+    ADD COLUMN IF NOT EXISTS Previous  Nullable(String);
+
+-- history of goods without duplicates of redelivered events which aren't merged yet
+CREATE VIEW IF NOT EXISTS goods_history AS
+SELECT *
+FROM goods FINAL;
+
+-- states of distinct event IDs, so duplicates of redelivered events aren't counted
+CREATE TABLE IF NOT EXISTS goods_events_hourly
+(
+    ProjectId INT,
+    EventType LowCardinality(String),
+    Hour      DateTime('UTC'),
+    Events    AggregateFunction(uniqExact, UUID)
+
+) ENGINE = AggregatingMergeTree()
+      ORDER BY (ProjectId, EventType, Hour);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS goods_events_hourly_mv TO goods_events_hourly AS
+SELECT ProjectId, EventType, toStartOfHour(EventTime, 'UTC') AS Hour, uniqExactState(EventId) AS Events
+FROM goods
+GROUP BY ProjectId, EventType, Hour;
+
+CREATE TABLE IF NOT EXISTS goods_events_daily
+(
+    ProjectId INT,
+    EventType LowCardinality(String),
+    Day       Date,
+    Id        INT,
+    Events    AggregateFunction(uniqExact, UUID)
+
+) ENGINE = AggregatingMergeTree()
+      ORDER BY (ProjectId, EventType, Day, Id);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS goods_events_daily_mv TO goods_events_daily AS
+SELECT ProjectId, EventType, toDate(EventTime, 'UTC') AS Day, Id, uniqExactState(EventId) AS Events
+FROM goods
+GROUP BY ProjectId, EventType, Day, Id;
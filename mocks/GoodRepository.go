@@ -81,21 +81,81 @@ func (_m *GoodRepository) Get(ctx context.Context, id int) (*entity.Good, error)
 	return r0, r1
 }
 
-// List provides a mock function with given fields: ctx, limit, offset
-func (_m *GoodRepository) List(ctx context.Context, limit int, offset int) ([]*entity.Good, error) {
-	ret := _m.Called(ctx, limit, offset)
+// GetMany provides a mock function with given fields: ctx, ids
+func (_m *GoodRepository) GetMany(ctx context.Context, ids []int) ([]*entity.Good, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMany")
+	}
+
+	var r0 []*entity.Good
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]*entity.Good, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []*entity.Good); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Good)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, projectId, limit, offset
+func (_m *GoodRepository) List(ctx context.Context, projectId int, limit int, offset int) ([]*entity.Good, error) {
+	ret := _m.Called(ctx, projectId, limit, offset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
 	}
 
+	var r0 []*entity.Good
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) ([]*entity.Good, error)); ok {
+		return rf(ctx, projectId, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []*entity.Good); ok {
+		r0 = rf(ctx, projectId, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Good)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, projectId, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAfter provides a mock function with given fields: ctx, afterId, limit
+func (_m *GoodRepository) ListAfter(ctx context.Context, afterId int, limit int) ([]*entity.Good, error) {
+	ret := _m.Called(ctx, afterId, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAfter")
+	}
+
 	var r0 []*entity.Good
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*entity.Good, error)); ok {
-		return rf(ctx, limit, offset)
+		return rf(ctx, afterId, limit)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*entity.Good); ok {
-		r0 = rf(ctx, limit, offset)
+		r0 = rf(ctx, afterId, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entity.Good)
@@ -103,7 +163,7 @@ func (_m *GoodRepository) List(ctx context.Context, limit int, offset int) ([]*e
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, limit, offset)
+		r1 = rf(ctx, afterId, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -159,6 +219,24 @@ func (_m *GoodRepository) Update(ctx context.Context, good *entity.Good) error {
 	return r0
 }
 
+// UpdateFields provides a mock function with given fields: ctx, good, fields
+func (_m *GoodRepository) UpdateFields(ctx context.Context, good *entity.Good, fields []string) error {
+	ret := _m.Called(ctx, good, fields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFields")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Good, []string) error); ok {
+		r0 = rf(ctx, good, fields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewGoodRepository creates a new instance of GoodRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewGoodRepository(t interface {
@@ -81,9 +81,39 @@ func (_m *GoodUsecase) Get(ctx context.Context, id int) (*entity.Good, error) {
 	return r0, r1
 }
 
-// List provides a mock function with given fields: ctx, limit, offset
-func (_m *GoodUsecase) List(ctx context.Context, limit int, offset int) ([]*entity.Good, error) {
-	ret := _m.Called(ctx, limit, offset)
+// GetMany provides a mock function with given fields: ctx, ids
+func (_m *GoodUsecase) GetMany(ctx context.Context, ids []int) ([]*entity.Good, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMany")
+	}
+
+	var r0 []*entity.Good
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]*entity.Good, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []*entity.Good); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Good)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, projectId, limit, offset
+func (_m *GoodUsecase) List(ctx context.Context, projectId int, limit int, offset int) ([]*entity.Good, error) {
+	ret := _m.Called(ctx, projectId, limit, offset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -91,19 +121,19 @@ func (_m *GoodUsecase) List(ctx context.Context, limit int, offset int) ([]*enti
 
 	var r0 []*entity.Good
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*entity.Good, error)); ok {
-		return rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) ([]*entity.Good, error)); ok {
+		return rf(ctx, projectId, limit, offset)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*entity.Good); ok {
-		r0 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []*entity.Good); ok {
+		r0 = rf(ctx, projectId, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entity.Good)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, projectId, limit, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -111,6 +141,24 @@ func (_m *GoodUsecase) List(ctx context.Context, limit int, offset int) ([]*enti
 	return r0, r1
 }
 
+// Patch provides a mock function with given fields: ctx, good, patch
+func (_m *GoodUsecase) Patch(ctx context.Context, good *entity.Good, patch entity.GoodPatch) error {
+	ret := _m.Called(ctx, good, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Good, entity.GoodPatch) error); ok {
+		r0 = rf(ctx, good, patch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Reprioritize provides a mock function with given fields: ctx, id, newPriority
 func (_m *GoodUsecase) Reprioritize(ctx context.Context, id int, newPriority int) (map[int]int, error) {
 	ret := _m.Called(ctx, id, newPriority)
@@ -15,17 +15,47 @@ type LoggerRepository struct {
 	mock.Mock
 }
 
-// SaveList provides a mock function with given fields: ctx, goods
-func (_m *LoggerRepository) SaveList(ctx context.Context, goods []*entity.Good) error {
-	ret := _m.Called(ctx, goods)
+// ListHistory provides a mock function with given fields: ctx, filter
+func (_m *LoggerRepository) ListHistory(ctx context.Context, filter entity.HistoryFilter) ([]*entity.Event, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListHistory")
+	}
+
+	var r0 []*entity.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.HistoryFilter) ([]*entity.Event, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.HistoryFilter) []*entity.Event); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.HistoryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveList provides a mock function with given fields: ctx, events
+func (_m *LoggerRepository) SaveList(ctx context.Context, events []*entity.Event) error {
+	ret := _m.Called(ctx, events)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveList")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []*entity.Good) error); ok {
-		r0 = rf(ctx, goods)
+	if rf, ok := ret.Get(0).(func(context.Context, []*entity.Event) error); ok {
+		r0 = rf(ctx, events)
 	} else {
 		r0 = ret.Error(0)
 	}